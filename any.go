@@ -0,0 +1,120 @@
+package protohash
+
+import (
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// UnresolvedAnyPolicy selects how an Any whose type URL cannot be resolved is
+// hashed by a hasher created with WithResolver.
+type UnresolvedAnyPolicy int
+
+const (
+	// UnresolvedAnyError makes hashing fail. It is the default policy.
+	UnresolvedAnyError UnresolvedAnyPolicy = iota
+
+	// UnresolvedAnyRaw hashes the Any as a plain message, that is as its type
+	// URL and the raw serialized bytes of its value.
+	UnresolvedAnyRaw
+
+	// UnresolvedAnySkip hashes the Any as if it were empty, ignoring both its
+	// type URL and its value.
+	UnresolvedAnySkip
+)
+
+// WithResolver makes the hasher unpack google.protobuf.Any using r. The
+// message held by an Any is hashed like any other message, bound to its full
+// name, so two Anys holding equal messages hash the same however their values
+// were serialized. Without a resolver an Any is hashed as a plain message.
+func WithResolver(r protoregistry.MessageTypeResolver) HashOption {
+	return func(ph *ProtoHasher) {
+		ph.resolver = r
+	}
+}
+
+// WithUnresolvedAny sets the policy for Anys whose type URL cannot be resolved
+// by the resolver given to WithResolver.
+func WithUnresolvedAny(policy UnresolvedAnyPolicy) HashOption {
+	return func(ph *ProtoHasher) {
+		ph.unresolvedAny = policy
+	}
+}
+
+// unpackAny returns the message held by an Any. It returns nil if the Any is
+// empty, or if its type URL cannot be resolved and the policy allows it.
+func (ph *ProtoHasher) unpackAny(msg protoreflect.Message) (protoreflect.Message, error) {
+	fields := msg.Descriptor().Fields()
+	url := msg.Get(fields.ByName("type_url")).String()
+	value := msg.Get(fields.ByName("value")).Bytes()
+	if url == "" && len(value) == 0 {
+		return nil, nil
+	}
+
+	mt, err := ph.resolver.FindMessageByURL(url)
+	if err != nil {
+		if errors.Is(err, protoregistry.NotFound) && ph.unresolvedAny != UnresolvedAnyError {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "cannot resolve %s type URL %q", anyFullName, url)
+	}
+
+	m := mt.New()
+	opts := proto.UnmarshalOptions{AllowPartial: ph.allowPartial}
+	if err := opts.Unmarshal(value, m.Interface()); err != nil {
+		return nil, errors.Wrapf(err, "cannot unpack %s holding %s", anyFullName, mt.Descriptor().FullName())
+	}
+	return m, nil
+}
+
+// hashAny hashes an Any as the full name of the message it holds followed by
+// the hash of that message.
+func (hs *hashState) hashAny(msg protoreflect.Message) (uint64, error) {
+	m, err := hs.unpackAny(msg)
+	if err != nil {
+		return 0, err
+	}
+
+	switch {
+	case m != nil:
+	case hs.unresolvedAny == UnresolvedAnySkip:
+		return 0, nil
+	default:
+		return hs.hashFields(hs.plan(msg.Descriptor()), msg)
+	}
+
+	hn, err := hs.hashString(string(m.Descriptor().FullName()))
+	if err != nil {
+		return 0, err
+	}
+
+	hm, err := hs.hashMessage(m)
+	if err != nil {
+		return 0, err
+	}
+	return hs.hashUpdateOrdered(hn, hm)
+}
+
+// objectHashAny is the ObjectHash counterpart of hashAny. A resolved Any is
+// hashed as the list of the full name and the message it holds.
+func (ph *ProtoHasher) objectHashAny(msg protoreflect.Message) ([]byte, error) {
+	m, err := ph.unpackAny(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case m != nil:
+	case ph.unresolvedAny == UnresolvedAnySkip:
+		return objectHashDict(nil), nil
+	default:
+		return ph.objectHashFields(msg, objectField{})
+	}
+
+	hm, err := ph.objectHashMessage(m, objectField{})
+	if err != nil {
+		return nil, err
+	}
+	return objectHashList([][]byte{objectHashUnicode(string(m.Descriptor().FullName())), hm}), nil
+}
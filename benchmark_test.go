@@ -0,0 +1,101 @@
+package protohash_test
+
+import (
+	"testing"
+
+	"github.com/aserto-dev/go-protohash"
+	"github.com/aserto-dev/go-protohash/tests/api/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+var benchmarkMessages = []struct {
+	name string
+	msg  proto.Message
+}{
+	{"Simple", &api.Simple{
+		BoolField:     true,
+		BytesField:    []byte("bytes"),
+		DoubleField:   1.5,
+		Fixed32Field:  32,
+		Fixed64Field:  64,
+		FloatField:    2.5,
+		Int32Field:    -32,
+		Int64Field:    -64,
+		Sfixed32Field: -32,
+		Sfixed64Field: -64,
+		Sint32Field:   -32,
+		Sint64Field:   -64,
+		StringField:   "string",
+		Uint32Field:   32,
+		Uint64Field:   64,
+		SimpleField:   &api.Simple{StringField: "nested"},
+	}},
+	{"Repetitive", &api.Repetitive{
+		BoolField:   []bool{true, false, true},
+		BytesField:  [][]byte{[]byte("a"), []byte("b"), []byte("c")},
+		DoubleField: []float64{1, 2, 3},
+		Int32Field:  []int32{1, 2, 3, 4, 5, 6, 7, 8},
+		Int64Field:  []int64{1, 2, 3, 4, 5, 6, 7, 8},
+		StringField: []string{"foo", "bar", "baz", "qux"},
+		Uint64Field: []uint64{1, 2, 3, 4, 5, 6, 7, 8},
+		SimpleField: []*api.Simple{{Int32Field: 1}, {StringField: "two"}},
+	}},
+	{"BoolMaps", &api.BoolMaps{
+		BoolToString: map[bool]string{true: "true", false: "false"},
+		BoolToSimple: map[bool]*api.Simple{true: {BoolField: true}},
+	}},
+	{"IntMaps", &api.IntMaps{
+		IntToString: map[int64]string{1: "one", 2: "two", 3: "three", 4: "four"},
+		IntToSimple: map[int64]*api.Simple{1: {Int64Field: 1}, 2: {Int64Field: 2}},
+	}},
+	{"StringMaps", &api.StringMaps{
+		StringToString: map[string]string{"foo": "bar", "baz": "qux", "quux": "corge"},
+		StringToInt64:  map[string]int64{"one": 1, "two": 2, "three": 3},
+		StringToDouble: map[string]float64{"pi": 3.14159, "e": 2.71828},
+	}},
+}
+
+func BenchmarkHashMessage(b *testing.B) {
+	hashers := []struct {
+		name string
+		ph   *protohash.ProtoHasher
+	}{
+		{"Generated", protohash.New()},
+		{"Reflection", protohash.New(protohash.WithReflectionOnly())},
+		{"Tagged", protohash.New(protohash.WithReflectionOnly(), protohash.WithTaggedEncoding())},
+	}
+
+	for _, h := range hashers {
+		for _, m := range benchmarkMessages {
+			ph, msg := h.ph, m.msg
+			b.Run(h.name+"/"+m.name, func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					if _, err := ph.HashMessage(msg); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkHashWire(b *testing.B) {
+	ph := protohash.New()
+	for _, m := range benchmarkMessages {
+		wire, err := proto.Marshal(m.msg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		desc := m.msg.ProtoReflect().Descriptor()
+		b.Run(m.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := ph.HashWire(desc, wire); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
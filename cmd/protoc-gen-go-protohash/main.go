@@ -0,0 +1,242 @@
+// protoc-gen-go-protohash is a protoc plugin that generates, for every message,
+// a ProtoHash method that hashes the message without going through
+// protoreflect. The generated methods give the same results as the reflective
+// protohash.ProtoHasher, which uses them when they are present.
+//
+// Messages that declare hashing options from protohash/options.proto, or that
+// have fields declaring them, get no ProtoHash method and are hashed through
+// reflection.
+//
+// It is meant to run alongside protoc-gen-go, with the same output options.
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	protohashpb "github.com/aserto-dev/go-protohash/protohash"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	mathPackage        = protogen.GoImportPath("math")
+	protohashPackage   = protogen.GoImportPath("github.com/aserto-dev/go-protohash")
+	protohashpbPackage = protogen.GoImportPath("github.com/aserto-dev/go-protohash/protohash")
+)
+
+func main() {
+	protogen.Options{}.Run(func(gen *protogen.Plugin) error {
+		for _, f := range gen.Files {
+			// The options themselves are imported by protohash, which the
+			// generated methods import in turn.
+			if f.Generate && f.GoImportPath != protohashpbPackage {
+				generateFile(gen, f)
+			}
+		}
+		return nil
+	})
+}
+
+func generateFile(gen *protogen.Plugin, f *protogen.File) {
+	messages := allMessages(f.Messages)
+	if len(messages) == 0 {
+		return
+	}
+
+	g := gen.NewGeneratedFile(f.GeneratedFilenamePrefix+"_protohash.pb.go", f.GoImportPath)
+	g.P("// Code generated by protoc-gen-go-protohash. DO NOT EDIT.")
+	g.P("// source: ", f.Desc.Path())
+	g.P()
+	g.P("package ", f.GoPackageName)
+
+	for _, m := range messages {
+		generateMessage(g, m)
+	}
+}
+
+// allMessages returns messages and the messages nested in them, except for map
+// entries, messages with hashing options and messages with extension ranges,
+// whose extensions are only known at run time.
+func allMessages(messages []*protogen.Message) []*protogen.Message {
+	var all []*protogen.Message
+	for _, m := range messages {
+		if !m.Desc.IsMapEntry() && !hasHashingOptions(m) && m.Desc.ExtensionRanges().Len() == 0 {
+			all = append(all, m)
+		}
+		all = append(all, allMessages(m.Messages)...)
+	}
+	return all
+}
+
+// hasHashingOptions reports whether m or one of its fields declares options
+// from protohash/options.proto.
+func hasHashingOptions(m *protogen.Message) bool {
+	if proto.HasExtension(m.Desc.Options(), protohashpb.E_Message) {
+		return true
+	}
+	for _, field := range m.Fields {
+		if proto.HasExtension(field.Desc.Options(), protohashpb.E_Field) {
+			return true
+		}
+	}
+	return false
+}
+
+func generateMessage(g *protogen.GeneratedFile, m *protogen.Message) {
+	state := g.QualifiedGoIdent(protohashPackage.Ident("State"))
+
+	g.P()
+	g.P("// ProtoHash returns the hash of x, as protohash.ProtoHasher.HashMessage does.")
+	g.P("func (x *", m.GoIdent, ") ProtoHash(s *", state, ") (uint64, error) {")
+	if len(m.Fields) == 0 {
+		g.P("return 0, nil")
+		g.P("}")
+		return
+	}
+
+	g.P("if x == nil {")
+	g.P("return 0, nil")
+	g.P("}")
+	g.P()
+	g.P("var (")
+	g.P("h, hv uint64")
+	g.P("err   error")
+	g.P(")")
+	g.P()
+
+	fields := append([]*protogen.Field(nil), m.Fields...)
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Desc.Number() < fields[j].Desc.Number()
+	})
+	for _, field := range fields {
+		generateField(g, field)
+	}
+
+	g.P("return h, nil")
+	g.P("}")
+}
+
+func generateField(g *protogen.GeneratedFile, field *protogen.Field) {
+	fd := field.Desc
+
+	switch {
+	case fd.IsMap():
+		key, value := field.Message.Fields[0], field.Message.Fields[1]
+		g.P("if len(x.", field.GoName, ") > 0 {")
+		g.P("var hm uint64")
+		g.P("for k, v := range x.", field.GoName, " {")
+		g.P("hk, err := ", hashCall(g, key, "k"))
+		returnOnError(g)
+		g.P("hv, err := ", hashCall(g, value, "v"))
+		returnOnError(g)
+		g.P("he, err := s.CombineOrdered(hk, hv)")
+		returnOnError(g)
+		g.P("hm = s.CombineUnordered(hm, he)")
+		g.P("}")
+		g.P("if hv, err = s.FinishUnordered(hm); err != nil {")
+		g.P("return 0, err")
+		g.P("}")
+		combineField(g, fd.Number())
+		g.P("}")
+
+	case fd.IsList():
+		g.P("if len(x.", field.GoName, ") > 0 {")
+		g.P("var hl uint64")
+		g.P("for i := len(x.", field.GoName, ") - 1; i >= 0; i-- {")
+		hashValue(g, field, "x."+field.GoName+"[i]")
+		g.P("if hl, err = s.CombineOrdered(hl, hv); err != nil {")
+		g.P("return 0, err")
+		g.P("}")
+		g.P("}")
+		g.P("hv = hl")
+		combineField(g, fd.Number())
+		g.P("}")
+
+	case field.Oneof != nil && !field.Oneof.Desc.IsSynthetic():
+		g.P("if o, ok := x.", field.Oneof.GoName, ".(*", field.GoIdent, "); ok {")
+		hashValue(g, field, "o."+field.GoName)
+		combineField(g, fd.Number())
+		g.P("}")
+
+	default:
+		value := "x." + field.GoName
+		if fd.HasPresence() && fd.Message() == nil && fd.Kind() != protoreflect.BytesKind {
+			value = "*" + value
+		}
+		g.P("if ", populated(g, field), " {")
+		hashValue(g, field, value)
+		combineField(g, fd.Number())
+		g.P("}")
+	}
+	g.P()
+}
+
+// populated returns the condition under which a singular field that is not part
+// of a oneof is populated, as reported by protoreflect.Message.Has.
+func populated(g *protogen.GeneratedFile, field *protogen.Field) string {
+	fd := field.Desc
+	value := "x." + field.GoName
+	if fd.HasPresence() {
+		return value + " != nil"
+	}
+
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return value
+	case protoreflect.StringKind:
+		return value + ` != ""`
+	case protoreflect.BytesKind:
+		return "len(" + value + ") > 0"
+	case protoreflect.FloatKind:
+		// -0 is populated.
+		return g.QualifiedGoIdent(mathPackage.Ident("Float32bits")) + "(" + value + ") != 0"
+	case protoreflect.DoubleKind:
+		return g.QualifiedGoIdent(mathPackage.Ident("Float64bits")) + "(" + value + ") != 0"
+	default:
+		return value + " != 0"
+	}
+}
+
+// hashCall returns the call that hashes value, a value of field.
+func hashCall(g *protogen.GeneratedFile, field *protogen.Field, value string) string {
+	switch field.Desc.Kind() {
+	case protoreflect.BoolKind:
+		return fmt.Sprintf("s.HashBool(%s)", value)
+	case protoreflect.EnumKind:
+		return fmt.Sprintf("s.HashEnum(int32(%s))", value)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed32Kind, protoreflect.Sfixed64Kind:
+		return fmt.Sprintf("s.HashInt(int64(%s))", value)
+	case protoreflect.Uint32Kind, protoreflect.Uint64Kind, protoreflect.Fixed32Kind, protoreflect.Fixed64Kind:
+		return fmt.Sprintf("s.HashUint(uint64(%s))", value)
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return fmt.Sprintf("s.HashFloat(float64(%s))", value)
+	case protoreflect.StringKind:
+		return fmt.Sprintf("s.HashString(%s)", value)
+	case protoreflect.BytesKind:
+		return fmt.Sprintf("s.HashBytes(%s)", value)
+	default:
+		return fmt.Sprintf("s.HashMessage(%s)", value)
+	}
+}
+
+func returnOnError(g *protogen.GeneratedFile) {
+	g.P("if err != nil {")
+	g.P("return 0, err")
+	g.P("}")
+}
+
+// hashValue stores the hash of value, a value of field, in hv.
+func hashValue(g *protogen.GeneratedFile, field *protogen.Field, value string) {
+	g.P("if hv, err = ", hashCall(g, field, value), "; err != nil {")
+	g.P("return 0, err")
+	g.P("}")
+}
+
+// combineField mixes hv into h.
+func combineField(g *protogen.GeneratedFile, num protoreflect.FieldNumber) {
+	g.P("if h, err = s.CombineField(h, ", int32(num), ", hv); err != nil {")
+	g.P("return 0, err")
+	g.P("}")
+}
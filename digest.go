@@ -0,0 +1,172 @@
+package protohash
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"hash"
+	"hash/fnv"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxPooledDigests is the number of digests above which the arena of a state
+// is dropped rather than returned to the pool, so that hashing a large message
+// does not keep its digests alive.
+const maxPooledDigests = 1024
+
+// defaultDigestSize is the size of the digests returned by HashMessageDigest
+// when neither WithDigestSize nor WithDigestFactory is used.
+const defaultDigestSize = sha256.Size
+
+// WithDigestSize selects the size in bytes of the digests returned by
+// HashMessageDigest: 16 uses FNV-128a, 32 uses SHA-256 and 64 uses SHA-512.
+// Other sizes make HashMessageDigest fail, unless WithDigestFactory is used.
+func WithDigestSize(size int) HashOption {
+	return func(ph *ProtoHasher) {
+		ph.digestSize = size
+		ph.newDigest = nil
+	}
+}
+
+// WithDigestFactory makes HashMessageDigest use hash.Hash instances created by
+// newDigest, such as sha512.New512_256. The digests it returns have the size of
+// those of newDigest. Instances are pooled and each call uses its own, so
+// newDigest must return a new hash.Hash every time it is called.
+func WithDigestFactory(newDigest func() hash.Hash) HashOption {
+	return func(ph *ProtoHasher) {
+		ph.newDigest = newDigest
+	}
+}
+
+// digestFactory returns the function creating the hash.Hash of the given size
+// used when no factory is provided, or nil if there is none.
+func digestFactory(size int) func() hash.Hash {
+	switch size {
+	case 0, sha256.Size:
+		return sha256.New
+	case 16:
+		return fnv.New128a
+	case sha512.Size:
+		return sha512.New
+	default:
+		return nil
+	}
+}
+
+// HashMessageDigest returns the digest of msg. Messages are traversed and their
+// values combined following the same rules as HashMessage, and so under the
+// same options, but every hash is a full digest of the hash.Hash selected by
+// WithDigestSize or WithDigestFactory rather than a uint64.
+//
+// Messages that implement Hashable are hashed by their generated ProtoHash
+// method, as they are by HashMessage.
+func (ph *ProtoHasher) HashMessageDigest(msg proto.Message) ([]byte, error) {
+	m, err := ph.reflectMessage(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ph.checkOptions(); err != nil {
+		return nil, err
+	}
+
+	if ph.newDigest == nil {
+		return nil, errors.Errorf("unsupported digest size: %d", ph.digestSize)
+	}
+
+	hs := ph.digestStates.Get().(*hashState)
+	defer ph.releaseDigestState(hs)
+
+	if err := hs.selectPaths(m.Descriptor()); err != nil {
+		return nil, err
+	}
+
+	h, err := hs.hashMessage(m)
+	if err != nil {
+		return nil, rootError(err, m.Descriptor())
+	}
+
+	return append([]byte(nil), hs.digests.get(h)...), nil
+}
+
+// releaseDigestState returns hs to the pool, with an arena holding no more
+// than the zero digest.
+func (ph *ProtoHasher) releaseDigestState(hs *hashState) {
+	hs.digests.release()
+	ph.digestStates.Put(hs)
+}
+
+// digestArena stores the digests computed by a HashMessageDigest call. Hash
+// values are handles to the digests, so that the traversal is shared with
+// HashMessage: handle i is the i-th digest of the arena and handle 0 the zero
+// digest, which stands for empty messages and lists as 0 does for HashMessage.
+type digestArena struct {
+	size int
+	b    []byte
+}
+
+// reset drops the digests of the previous call, keeping the zero digest.
+func (a *digestArena) reset() {
+	a.b = a.b[:a.size]
+	for i := range a.b {
+		a.b[i] = 0
+	}
+}
+
+// release resets the arena, and replaces its buffer if it grew beyond
+// maxPooledDigests digests.
+func (a *digestArena) release() {
+	if cap(a.b) > maxPooledDigests*a.size {
+		a.b = make([]byte, a.size)
+		return
+	}
+	a.reset()
+}
+
+func (a *digestArena) get(handle uint64) []byte {
+	start := int(handle) * a.size
+	return a.b[start : start+a.size]
+}
+
+// last returns the handle of the last digest appended to the arena.
+func (a *digestArena) last() uint64 {
+	return uint64(len(a.b)/a.size - 1)
+}
+
+// xor stores the XOR of the digests x and y and returns its handle.
+func (a *digestArena) xor(x, y uint64) uint64 {
+	// The zero digest is appended first, as the arena may be reallocated.
+	a.b = append(a.b, a.b[:a.size]...)
+	out := a.b[len(a.b)-a.size:]
+	dx, dy := a.get(x), a.get(y)
+	for i := range out {
+		out[i] = dx[i] ^ dy[i]
+	}
+	return a.last()
+}
+
+// add stores the sum of the digests x and y, as little-endian integers modulo
+// 2^(8*size), and returns its handle.
+func (a *digestArena) add(x, y uint64) uint64 {
+	a.b = append(a.b, a.b[:a.size]...)
+	out := a.b[len(a.b)-a.size:]
+	dx, dy := a.get(x), a.get(y)
+	var carry uint16
+	for i := range out {
+		s := uint16(dx[i]) + uint16(dy[i]) + carry
+		out[i] = byte(s)
+		carry = s >> 8
+	}
+	return a.last()
+}
+
+// sum returns the hash of the bytes written to hs.h since it was last reset.
+func (hs *hashState) sum() uint64 {
+	if hs.h64 != nil {
+		return hs.h64.Sum64()
+	}
+
+	hs.digests.b = hs.h.Sum(hs.digests.b)
+	return hs.digests.last()
+}
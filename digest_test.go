@@ -0,0 +1,30 @@
+package protohash
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDigestArenaRelease(t *testing.T) {
+	a := digestArena{size: sha256.Size, b: make([]byte, sha256.Size)}
+	for i := 0; i < 10; i++ {
+		a.b = append(a.b, make([]byte, a.size)...)
+		a.b[len(a.b)-1] = byte(i + 1)
+	}
+
+	// Small arenas keep their buffer, reset to the zero digest.
+	b := a.b
+	a.release()
+	require.Equal(t, make([]byte, a.size), a.b)
+	require.Equal(t, &b[0], &a.b[0])
+
+	// Large arenas drop theirs.
+	for a.last() < maxPooledDigests {
+		a.b = append(a.b, make([]byte, a.size)...)
+	}
+	a.release()
+	require.Equal(t, make([]byte, a.size), a.b)
+	require.LessOrEqual(t, cap(a.b), maxPooledDigests*a.size)
+}
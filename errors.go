@@ -0,0 +1,94 @@
+package protohash
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/reflect/protopath"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var (
+	// ErrNilMessage is returned when the message to hash is nil.
+	ErrNilMessage = errors.New("protohash: message is nil")
+
+	// ErrInvalidMessage is returned, wrapped, when the message to hash is
+	// invalid, such as a typed nil pointer or a message with missing required
+	// fields.
+	ErrInvalidMessage = errors.New("protohash: message is invalid")
+
+	// ErrInvalidPath is returned, wrapped, when a path given to WithFieldMask
+	// or WithExcludePaths does not match the message to hash.
+	ErrInvalidPath = errors.New("protohash: invalid path")
+
+	// ErrUnsupportedOption is returned, wrapped, by ObjectHash when the hasher
+	// has options that ObjectHash cannot honour.
+	ErrUnsupportedOption = errors.New("protohash: option not supported by ObjectHash")
+)
+
+// invalidMessage returns an error wrapping ErrInvalidMessage with a reason.
+func invalidMessage(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrInvalidMessage, fmt.Sprintf(format, args...))
+}
+
+// unsupportedOption returns an error wrapping ErrUnsupportedOption for option.
+func unsupportedOption(option string) error {
+	return fmt.Errorf("%w: %s", ErrUnsupportedOption, option)
+}
+
+// FieldError is returned when a field cannot be hashed, such as an Any whose
+// type URL cannot be resolved.
+type FieldError struct {
+	// Path is the path of the field from the hashed message, starting with a
+	// protopath.Root step, and going through list indexes and map keys.
+	Path protopath.Path
+
+	// Kind is the kind of the field.
+	Kind protoreflect.Kind
+
+	// Err is the reason why the field cannot be hashed.
+	Err error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("protohash: cannot hash %s field %s: %v", e.Kind, e.Path, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// withStep prepends step to the path of err if it is a *FieldError, and makes
+// err a *FieldError of the field fd otherwise.
+func withStep(err error, step protopath.Step, fd protoreflect.FieldDescriptor) error {
+	fe, ok := err.(*FieldError)
+	if !ok {
+		fe = &FieldError{Kind: fd.Kind(), Err: err}
+	}
+	fe.Path = append(protopath.Path{step}, fe.Path...)
+	return fe
+}
+
+// fieldError prepends the field fd to the path of err.
+func fieldError(err error, fd protoreflect.FieldDescriptor) error {
+	return withStep(err, protopath.FieldAccess(fd), fd)
+}
+
+// listIndexError prepends the index i of a list of the field fd to the path of
+// err.
+func listIndexError(err error, fd protoreflect.FieldDescriptor, i int) error {
+	return withStep(err, protopath.ListIndex(i), fd)
+}
+
+// mapIndexError prepends the key k of a map of the field fd to the path of err.
+func mapIndexError(err error, fd protoreflect.FieldDescriptor, k protoreflect.MapKey) error {
+	return withStep(err, protopath.MapIndex(k), fd)
+}
+
+// rootError prepends the message md to the path of err if it is a *FieldError.
+func rootError(err error, md protoreflect.MessageDescriptor) error {
+	if fe, ok := err.(*FieldError); ok {
+		fe.Path = append(protopath.Path{protopath.Root(md)}, fe.Path...)
+	}
+	return err
+}
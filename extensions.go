@@ -0,0 +1,155 @@
+package protohash
+
+import (
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// ExtensionMode selects how the extensions of messages are hashed.
+type ExtensionMode int
+
+const (
+	// ExtensionsByNumber identifies extensions by their field number, so that
+	// renaming an extension does not change the hash. It is the default mode.
+	ExtensionsByNumber ExtensionMode = iota
+
+	// ExtensionsByName identifies extensions by their full name, such as
+	// "tests.api.proto2.v1.label", so that renumbering an extension does not
+	// change the hash.
+	ExtensionsByName
+
+	// ExtensionsExcluded leaves extensions out of the hash.
+	ExtensionsExcluded
+)
+
+// Reserved field numbers, to which the unknown fields and the extensions of a
+// message are bound, so that they are told apart from its fields. The values of
+// the fields in a oneof are bound to oneofNumber by WithSchemaEvolution.
+const (
+	unknownFieldsNumber = 0
+	extensionsNumber    = uint64(protowire.MaxValidNumber) + 1
+	oneofNumber         = extensionsNumber + 1
+)
+
+// WithExtensions selects how the extensions populated on messages are hashed.
+// Unless they are excluded, the hash of every extension is bound to its
+// identity, and the extensions are combined as a set, which is mixed into the
+// hash of the message after its fields. An extension thus never hashes like a
+// field of the message it extends, and messages without extensions hash as
+// they do when extensions are excluded.
+//
+// Messages with extension ranges have no generated ProtoHash method.
+func WithExtensions(mode ExtensionMode) HashOption {
+	return func(ph *ProtoHasher) {
+		ph.extensionMode = mode
+	}
+}
+
+// WithExtensionResolver makes the hasher decode, with resolver, the extensions
+// still held as unknown fields, such as those of a message unmarshalled before
+// their type was registered, and hash them as extensions.
+//
+// HashWire decodes extensions with resolver as well, instead of
+// protoregistry.GlobalTypes, which proto.Unmarshal uses by default.
+func WithExtensionResolver(resolver protoregistry.ExtensionTypeResolver) HashOption {
+	return func(ph *ProtoHasher) {
+		ph.extensionResolver = resolver
+	}
+}
+
+// wireExtensionResolver returns the resolver of the extensions found by
+// HashWire.
+func (ph *ProtoHasher) wireExtensionResolver() protoregistry.ExtensionTypeResolver {
+	if ph.extensionResolver != nil {
+		return ph.extensionResolver
+	}
+	return protoregistry.GlobalTypes
+}
+
+// skipExtension reports whether the field fd is an extension left out of the
+// hash.
+func (hs *hashState) skipExtension(fd protoreflect.FieldDescriptor) bool {
+	return hs.extensionMode == ExtensionsExcluded && fd.IsExtension()
+}
+
+// addExtension binds the hash hv of the extension fd to its identity, and adds
+// it to the set of extensions h.
+func (hs *hashState) addExtension(h uint64, fd protoreflect.FieldDescriptor, hv uint64) (uint64, error) {
+	var err error
+	if hs.extensionMode == ExtensionsByName {
+		var hn uint64
+		hn, err = hs.hashString(string(fd.FullName()))
+		if err != nil {
+			return 0, err
+		}
+		hv, err = hs.hashUpdateOrdered(hn, hv)
+	} else {
+		hv, err = hs.hashUpdateNumber(uint64(fd.Number()), hv)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return hs.hashUpdateUnordered(h, hv), nil
+}
+
+// hashExtensions mixes the set of extensions ext into the message hash h.
+func (hs *hashState) hashExtensions(h, ext uint64) (uint64, error) {
+	ext, err := hs.hashFinishUnordered(ext)
+	if err != nil {
+		return 0, err
+	}
+	ext, err = hs.hashUpdateNumber(extensionsNumber, ext)
+	if err != nil {
+		return 0, err
+	}
+	return hs.hashUpdateOrdered(h, ext)
+}
+
+// resolveExtensions returns msg with the extensions held in its unknown fields
+// decoded with the resolver of WithExtensionResolver, or msg itself if there
+// are none.
+func (hs *hashState) resolveExtensions(msg protoreflect.Message) (protoreflect.Message, error) {
+	md := msg.Descriptor()
+	unknown := msg.GetUnknown()
+	if hs.extensionResolver == nil || hs.extensionMode == ExtensionsExcluded || len(unknown) == 0 || md.ExtensionRanges().Len() == 0 {
+		return msg, nil
+	}
+
+	if !hs.hasExtension(md, unknown) {
+		return msg, nil
+	}
+
+	// The unknown fields are unmarshalled into a copy of msg, where they are
+	// merged as proto.Unmarshal would, leaving those that are not extensions
+	// unknown.
+	resolved := proto.Clone(msg.Interface()).ProtoReflect()
+	resolved.SetUnknown(nil)
+	opts := proto.UnmarshalOptions{Merge: true, AllowPartial: true, Resolver: hs.extensionResolver}
+	if err := opts.Unmarshal(unknown, resolved.Interface()); err != nil {
+		return nil, err
+	}
+	return resolved, nil
+}
+
+// hasExtension reports whether the unknown fields b of a message described by
+// md hold an extension known to the resolver of WithExtensionResolver.
+func (hs *hashState) hasExtension(md protoreflect.MessageDescriptor, b protoreflect.RawFields) bool {
+	for len(b) > 0 {
+		num, _, n := protowire.ConsumeField(b)
+		if n < 0 {
+			// Malformed fields are reported when they are hashed.
+			return false
+		}
+		b = b[n:]
+
+		if !md.ExtensionRanges().Has(num) {
+			continue
+		}
+		if _, err := hs.extensionResolver.FindExtensionByNumber(md.FullName(), num); err == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+package protohash
+
+import (
+	"math"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Canonical NaNs. canonicalNaN64 is the bit pattern of math.NaN, a quiet NaN
+// with payload 1, and canonicalNaN32 the float32 quiet NaN with payload 1.
+const (
+	canonicalNaN64 = 0x7ff8000000000001
+	canonicalNaN32 = 0x7fc00001
+)
+
+// WithCanonicalFloats makes float and double values hash by the number they
+// denote rather than by their bits: every NaN, whatever its sign and payload,
+// hashes as the canonical NaN 0x7ff8000000000001, and -0 hashes as +0. Like
+// +0, -0 is then treated as unset in proto3 fields without explicit presence.
+//
+// Messages with float or double fields are hashed through reflection rather
+// than by their generated ProtoHash method.
+func WithCanonicalFloats() HashOption {
+	return func(ph *ProtoHasher) {
+		ph.canonicalFloats = true
+	}
+}
+
+// WithFloat32Bits makes float values hash as their 4-byte float32 encoding
+// rather than as the double they convert to, so that they no longer hash like
+// the equal double values and a change from float to double is detected. Under
+// WithCanonicalFloats, NaNs hash as the canonical float32 NaN 0x7fc00001.
+//
+// Messages with float fields are hashed through reflection rather than by
+// their generated ProtoHash method.
+func WithFloat32Bits() HashOption {
+	return func(ph *ProtoHasher) {
+		ph.float32Bits = true
+	}
+}
+
+// reflectFloats reports whether the values of the field fd are hashed in a way
+// the generated ProtoHash methods do not know about.
+func (ph *ProtoHasher) reflectFloats(fd protoreflect.FieldDescriptor) bool {
+	kind := fd.Kind()
+	if fd.IsMap() {
+		kind = fd.MapValue().Kind()
+	}
+	switch kind {
+	case protoreflect.FloatKind:
+		return ph.canonicalFloats || ph.float32Bits || ph.precision(fd) != nil
+	case protoreflect.DoubleKind:
+		return ph.canonicalFloats || ph.precision(fd) != nil
+	default:
+		return false
+	}
+}
+
+// canonicalFloat64 returns v with NaNs and -0 in their canonical form.
+func canonicalFloat64(v float64) float64 {
+	switch {
+	case v != v:
+		return math.Float64frombits(canonicalNaN64)
+	case v == 0:
+		return 0
+	default:
+		return v
+	}
+}
+
+// canonicalFloat32 returns v with NaNs and -0 in their canonical form.
+func canonicalFloat32(v float32) float32 {
+	switch {
+	case v != v:
+		return math.Float32frombits(canonicalNaN32)
+	case v == 0:
+		return 0
+	default:
+		return v
+	}
+}
+
+// hashFloat32 hashes a float value as its float32 encoding.
+func (hs *hashState) hashFloat32(v float32) (uint64, error) {
+	if hs.canonicalFloats {
+		v = canonicalFloat32(v)
+	}
+	return hs.hashFixed(floatIdentifier, 4, uint64(math.Float32bits(v)))
+}
+
+// hashFloat32Value hashes a float value, which is hashed as its float32
+// encoding under WithFloat32Bits and as a double otherwise.
+func hashFloat32Value(hs *hashState, v protoreflect.Value) (uint64, error) {
+	if !hs.float32Bits {
+		return hs.hashFloat(v.Float())
+	}
+	return hs.hashFloat32(float32(v.Float()))
+}
@@ -0,0 +1,86 @@
+package protohash
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Hashable is implemented by the messages for which protoc-gen-go-protohash
+// generated a ProtoHash method. HashMessage uses that method instead of
+// reflection, unless WithReflectionOnly is used.
+type Hashable interface {
+	proto.Message
+
+	// ProtoHash returns the hash of the message, computed with s. It must give
+	// the same result as HashMessage does through reflection.
+	ProtoHash(s *State) (uint64, error)
+}
+
+// State exposes the hashing primitives of a HashMessage call to the generated
+// ProtoHash methods. It is not meant to be used by other code.
+type State struct {
+	hs *hashState
+}
+
+// HashBool returns the hash of a bool value.
+func (s *State) HashBool(v bool) (uint64, error) {
+	return s.hs.hashBool(v)
+}
+
+// HashEnum returns the hash of an enum value.
+func (s *State) HashEnum(v int32) (uint64, error) {
+	return s.hs.hashEnum(v)
+}
+
+// HashInt returns the hash of a signed integer value of any size.
+func (s *State) HashInt(v int64) (uint64, error) {
+	return s.hs.hashInt(v)
+}
+
+// HashUint returns the hash of an unsigned integer value of any size.
+func (s *State) HashUint(v uint64) (uint64, error) {
+	return s.hs.hashUint(v)
+}
+
+// HashFloat returns the hash of a float or double value.
+func (s *State) HashFloat(v float64) (uint64, error) {
+	return s.hs.hashFloat(v)
+}
+
+// HashString returns the hash of a string value.
+func (s *State) HashString(v string) (uint64, error) {
+	return s.hs.hashString(v)
+}
+
+// HashBytes returns the hash of a bytes value.
+func (s *State) HashBytes(v []byte) (uint64, error) {
+	return s.hs.hashBytes(v)
+}
+
+// HashMessage returns the hash of a nested message.
+func (s *State) HashMessage(m proto.Message) (uint64, error) {
+	return s.hs.hashMessage(m.ProtoReflect())
+}
+
+// CombineField mixes the hash hv of the field number num into the message hash
+// h. Fields must be combined in field number order.
+func (s *State) CombineField(h uint64, num protoreflect.FieldNumber, hv uint64) (uint64, error) {
+	return s.hs.combineField(h, num, hv)
+}
+
+// CombineOrdered mixes hv into h. List elements are combined from the last to
+// the first.
+func (s *State) CombineOrdered(h, hv uint64) (uint64, error) {
+	return s.hs.hashUpdateOrdered(h, hv)
+}
+
+// CombineUnordered mixes hv into h regardless of order. It is used for map
+// entries, and the result must be passed to FinishUnordered.
+func (s *State) CombineUnordered(h, hv uint64) uint64 {
+	return s.hs.hashUpdateUnordered(h, hv)
+}
+
+// FinishUnordered hardens a hash built with CombineUnordered.
+func (s *State) FinishUnordered(h uint64) (uint64, error) {
+	return s.hs.hashFinishUnordered(h)
+}
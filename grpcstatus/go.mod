@@ -0,0 +1,20 @@
+module github.com/aserto-dev/go-protohash/grpcstatus
+
+go 1.17
+
+require (
+	github.com/aserto-dev/go-protohash v0.0.0-20261017185840-f4b4a368ada1
+	github.com/pkg/errors v0.9.1
+	github.com/stretchr/testify v1.7.0
+	google.golang.org/grpc v1.43.0
+	google.golang.org/protobuf v1.27.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/golang/protobuf v1.5.2 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/text v0.3.7 // indirect
+	google.golang.org/genproto v0.0.0-20210903162649-d08c68adba83 // indirect
+	gopkg.in/yaml.v3 v3.0.0-20210107192922-496545a6307b // indirect
+)
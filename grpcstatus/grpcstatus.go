@@ -0,0 +1,58 @@
+// Package grpcstatus converts the errors returned by protohash to gRPC status
+// errors, for servers that hash the messages they receive. It is a module of
+// its own, so that protohash itself does not depend on gRPC.
+package grpcstatus
+
+import (
+	"errors"
+
+	protohash "github.com/aserto-dev/go-protohash"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Code returns the gRPC code of err. Errors caused by the message are reported
+// as InvalidArgument: nil messages, invalid field paths, and fields that cannot
+// be decoded or resolved, such as an Any of an unknown type. Invalid messages
+// are reported as FailedPrecondition, and so are hashers with options that
+// ObjectHash does not support, as they are misconfigured. Errors that already
+// carry a gRPC status keep their code, and other errors, such as those of the
+// hash functions, are Internal.
+func Code(err error) codes.Code {
+	var fe *protohash.FieldError
+	switch {
+	case err == nil:
+		return codes.OK
+	case errors.Is(err, protohash.ErrUnsupportedOption):
+		return codes.FailedPrecondition
+	case errors.Is(err, protohash.ErrNilMessage):
+		return codes.InvalidArgument
+	case errors.Is(err, protohash.ErrInvalidMessage):
+		return codes.FailedPrecondition
+	case errors.Is(err, protohash.ErrInvalidPath):
+		return codes.InvalidArgument
+	case errors.As(err, &fe) && errors.Is(fe.Err, proto.Error):
+		return codes.InvalidArgument
+	}
+	if s, ok := status.FromError(err); ok {
+		return s.Code()
+	}
+	return codes.Internal
+}
+
+// Convert returns the gRPC status of err, with the code returned by Code.
+func Convert(err error) *status.Status {
+	if err == nil {
+		return nil
+	}
+	if s, ok := status.FromError(err); ok {
+		return s
+	}
+	return status.New(Code(err), err.Error())
+}
+
+// Error converts err to a gRPC status error, or returns nil if err is nil.
+func Error(err error) error {
+	return Convert(err).Err()
+}
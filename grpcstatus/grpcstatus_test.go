@@ -0,0 +1,63 @@
+package grpcstatus_test
+
+import (
+	"hash"
+	"hash/fnv"
+	"testing"
+
+	protohash "github.com/aserto-dev/go-protohash"
+	"github.com/aserto-dev/go-protohash/grpcstatus"
+	api "github.com/aserto-dev/go-protohash/tests/api/v1"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestCode(t *testing.T) {
+	ph := protohash.New(protohash.WithResolver(new(protoregistry.Types)))
+
+	_, errNil := ph.HashMessage(nil)
+	_, errInvalid := ph.HashMessage((*api.Simple)(nil))
+	_, errField := ph.HashMessage(&api.KnownTypes{AnyField: &anypb.Any{TypeUrl: "example.com/unknown.Message"}})
+	_, errPath := protohash.New(protohash.WithExcludePaths("no_field")).HashMessage(&api.Simple{})
+	_, errOption := protohash.New(protohash.WithUnknownFields()).ObjectHash(&api.Simple{})
+	_, errWrite := protohash.New(protohash.WithReflectionOnly(), protohash.WithHashFactory(func() hash.Hash64 {
+		return failingHash{fnv.New64a()}
+	})).HashMessage(&api.Simple{StringField: "a"})
+
+	tests := []struct {
+		err  error
+		code codes.Code
+	}{
+		{nil, codes.OK},
+		{errNil, codes.InvalidArgument},
+		{errInvalid, codes.FailedPrecondition},
+		{errField, codes.InvalidArgument},
+		{errPath, codes.InvalidArgument},
+		{errOption, codes.FailedPrecondition},
+		{errWrite, codes.Internal},
+		{status.Error(codes.Unavailable, "unavailable"), codes.Unavailable},
+		{errors.New("other"), codes.Internal},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.code, grpcstatus.Code(tt.err), "%v", tt.err)
+		require.Equal(t, tt.code, status.Code(grpcstatus.Error(tt.err)), "%v", tt.err)
+	}
+
+	require.Contains(t, grpcstatus.Convert(errField).Message(), "(tests.api.v1.KnownTypes).any_field")
+
+	var fe *protohash.FieldError
+	require.ErrorAs(t, errWrite, &fe)
+}
+
+// failingHash is a hash.Hash64 whose writes fail.
+type failingHash struct {
+	hash.Hash64
+}
+
+func (failingHash) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
@@ -0,0 +1,54 @@
+package protohash
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"hash"
+
+	"github.com/pkg/errors"
+)
+
+// sipHashKeySize is the size of a SipHash key.
+const sipHashKeySize = 16
+
+// WithKey makes the hasher keyed with key, so that hashes cannot be predicted,
+// nor collisions crafted, without knowing it. This protects maps keyed by the
+// hashes of untrusted messages against hash flooding.
+//
+// HashMessage and HashWire use SipHash-2-4 instead of the hash.Hash64 given by
+// WithHash64 or WithHashFactory, and HashMessageDigest uses HMAC over its
+// digest. The key is mixed into the hash of every value and every combination
+// of hashes, not only into the final result. A 16 byte key is used as is by
+// SipHash, while keys of other sizes are first reduced to 16 bytes with SHA-256.
+// ObjectHash is not affected by the key. HMAC is only as strong as the digest it
+// is built on, so keyed digests should use a cryptographic hash such as SHA-256.
+// An empty key, such as one read from an empty file, makes hashing fail.
+func WithKey(key []byte) HashOption {
+	key = append(make([]byte, 0, len(key)), key...)
+	return func(ph *ProtoHasher) {
+		if len(key) == 0 && ph.optionsErr == nil {
+			ph.optionsErr = errors.New("invalid hash key: empty")
+		}
+		ph.key = key
+	}
+}
+
+// applyKey replaces the hash functions of ph with their keyed counterparts, once
+// all options are applied.
+func (ph *ProtoHasher) applyKey() {
+	if ph.key == nil {
+		return
+	}
+
+	sipKey := ph.key
+	if len(sipKey) != sipHashKeySize {
+		sum := sha256.Sum256(sipKey)
+		sipKey = sum[:sipHashKeySize]
+	}
+	ph.newHash = func() hash.Hash64 { return newSipHash(sipKey) }
+	ph.shared = nil
+
+	if newDigest := ph.newDigest; newDigest != nil {
+		ph.newDigest = func() hash.Hash { return hmac.New(newDigest, ph.key) }
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/aserto-dev/mage-loot/common"
 	"github.com/aserto-dev/mage-loot/deps"
 	"github.com/magefile/mage/mg"
+	"github.com/magefile/mage/sh"
 )
 
 // All executes all build targets in dependency order.
@@ -22,6 +23,10 @@ func Deps() {
 
 // Gen executes code generators.
 func Gen() error {
+	if err := buildProtohashPlugin(); err != nil {
+		return err
+	}
+
 	if err := bufGenerate(); err != nil {
 		return err
 	}
@@ -42,6 +47,12 @@ func Test() error {
 	return common.Test()
 }
 
+// buildProtohashPlugin builds the protoc-gen-go-protohash plugin used by
+// buf.gen.yaml.
+func buildProtohashPlugin() error {
+	return sh.RunV("go", "build", "-o", "bin/protoc-gen-go-protohash", "./cmd/protoc-gen-go-protohash")
+}
+
 func bufGenerate() error {
 	return buf.Run(
 		buf.AddArg("generate"),
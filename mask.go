@@ -0,0 +1,185 @@
+package protohash
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// WithFieldMask makes the hasher hash only the fields selected by mask, as
+// paths of field names from the hashed message, such as "user.name". Paths
+// may go through repeated message fields and maps with message values, in
+// which case they select fields of every element or map value, but not into
+// well-known types. A nil or empty mask selects every field.
+//
+// Paths are validated against the descriptor of the hashed message, and
+// hashing fails with an error wrapping ErrInvalidPath when one of them does
+// not match. Messages are hashed through reflection below the fields that are
+// partially selected.
+func WithFieldMask(mask *fieldmaskpb.FieldMask) HashOption {
+	paths := append([]string(nil), mask.GetPaths()...)
+	return func(ph *ProtoHasher) {
+		ph.includePaths = paths
+	}
+}
+
+// WithExcludePaths makes the hasher leave the fields at paths out of the hash,
+// such as "metadata.updated_at". Paths follow the rules of WithFieldMask, and
+// apply to the fields it selects.
+func WithExcludePaths(paths ...string) HashOption {
+	paths = append([]string(nil), paths...)
+	return func(ph *ProtoHasher) {
+		ph.excludePaths = append(ph.excludePaths, paths...)
+	}
+}
+
+// pathNode is a tree of field paths. A leaf selects a field as a whole, while
+// the children of a node select fields of the message values of its field.
+type pathNode struct {
+	leaf     bool
+	children map[protoreflect.FieldNumber]*pathNode
+}
+
+// pathMasks are the paths given to WithFieldMask and WithExcludePaths, compiled
+// for a message type. A nil include selects every field, and a nil exclude
+// excludes none.
+type pathMasks struct {
+	include, exclude *pathNode
+	err              error
+}
+
+// masks returns the paths of the hasher compiled for the messages described
+// by md, compiling them the first time they are needed.
+func (ph *ProtoHasher) masks(md protoreflect.MessageDescriptor) *pathMasks {
+	if m, ok := ph.pathMasks.Load(md); ok {
+		return m.(*pathMasks)
+	}
+
+	m := &pathMasks{}
+	m.include, m.err = ph.compilePaths(md, ph.includePaths)
+	if m.err == nil {
+		m.exclude, m.err = ph.compilePaths(md, ph.excludePaths)
+	}
+
+	v, _ := ph.pathMasks.LoadOrStore(md, m)
+	return v.(*pathMasks)
+}
+
+func (ph *ProtoHasher) compilePaths(md protoreflect.MessageDescriptor, paths []string) (*pathNode, error) {
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	root := &pathNode{}
+	for _, path := range paths {
+		if err := ph.addPath(root, md, path); err != nil {
+			return nil, err
+		}
+	}
+	return root, nil
+}
+
+// addPath adds path, relative to the messages described by md, to the tree n.
+func (ph *ProtoHasher) addPath(n *pathNode, md protoreflect.MessageDescriptor, path string) error {
+	// The whole path is validated first, so that it is reported whether or not
+	// it is covered by another path.
+	fields, err := ph.pathFields(md, path)
+	if err != nil {
+		return err
+	}
+
+	for i, fd := range fields {
+		child := n.children[fd.Number()]
+		if child == nil {
+			child = &pathNode{}
+			if n.children == nil {
+				n.children = make(map[protoreflect.FieldNumber]*pathNode)
+			}
+			n.children[fd.Number()] = child
+		}
+
+		if i == len(fields)-1 {
+			// A whole field covers the paths to the fields of its values.
+			child.leaf = true
+			child.children = nil
+			return nil
+		}
+		if child.leaf {
+			return nil
+		}
+		n = child
+	}
+	return nil
+}
+
+// pathFields returns the fields named by path, relative to the messages
+// described by md.
+func (ph *ProtoHasher) pathFields(md protoreflect.MessageDescriptor, path string) ([]protoreflect.FieldDescriptor, error) {
+	names := strings.Split(path, ".")
+	fields := make([]protoreflect.FieldDescriptor, len(names))
+	for i, name := range names {
+		fd := md.Fields().ByName(protoreflect.Name(name))
+		if fd == nil {
+			return nil, invalidPath(path, "%s has no field %q", md.FullName(), name)
+		}
+		fields[i] = fd
+		if i == len(names)-1 {
+			break
+		}
+
+		if fd.IsMap() {
+			fd = fd.MapValue()
+		}
+		if fd.Message() == nil {
+			return nil, invalidPath(path, "%s is not a message field", fd.FullName())
+		}
+		if ph.plan(fd.Message()).wellKnown != nil {
+			return nil, invalidPath(path, "%s is a well-known type", fd.Message().FullName())
+		}
+		md = fd.Message()
+	}
+	return fields, nil
+}
+
+func invalidPath(path, format string, args ...interface{}) error {
+	return fmt.Errorf("%w %q: %s", ErrInvalidPath, path, fmt.Sprintf(format, args...))
+}
+
+// selectPaths sets the paths to apply to the messages described by md, which
+// is about to be hashed.
+func (hs *hashState) selectPaths(md protoreflect.MessageDescriptor) error {
+	hs.include, hs.exclude = nil, nil
+	if hs.includePaths == nil && hs.excludePaths == nil {
+		return nil
+	}
+
+	m := hs.masks(md)
+	hs.include, hs.exclude = m.include, m.exclude
+	return m.err
+}
+
+// selectField returns the paths to apply to the values of the field fd of a
+// message that is hashed with the paths include and exclude, and whether the
+// field is hashed at all.
+func selectField(include, exclude *pathNode, fd protoreflect.FieldDescriptor) (*pathNode, *pathNode, bool) {
+	if include != nil {
+		include = include.children[fd.Number()]
+		if include == nil {
+			return nil, nil, false
+		}
+		if include.leaf {
+			include = nil
+		}
+	}
+
+	if exclude != nil {
+		exclude = exclude.children[fd.Number()]
+		if exclude != nil && exclude.leaf {
+			return nil, nil, false
+		}
+	}
+
+	return include, exclude, true
+}
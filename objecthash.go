@@ -0,0 +1,387 @@
+package protohash
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"golang.org/x/text/unicode/norm"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Type identifiers as defined by https://github.com/benlaurie/objecthash. They
+// are also used as kind tags by the tagged encoding of HashMessage.
+const (
+	boolIdentifier    = 'b'
+	mapIdentifier     = 'd'
+	enumIdentifier    = 'e'
+	floatIdentifier   = 'f'
+	intIdentifier     = 'i'
+	listIdentifier    = 'l'
+	nilIdentifier     = 'n'
+	bytesIdentifier   = 'r'
+	setIdentifier     = 's'
+	unicodeIdentifier = 'u'
+)
+
+// WithFieldNamesAsKeys makes ObjectHash use field names instead of field numbers
+// as the keys of the dictionary a message is hashed as.
+func WithFieldNamesAsKeys() HashOption {
+	return func(ph *ProtoHasher) {
+		ph.fieldNamesAsKeys = true
+	}
+}
+
+// ObjectHash returns the SHA-256 ObjectHash of msg.
+//
+// The result follows the objecthash-proto rules, so it can be verified by any
+// other objecthash implementation: messages are hashed as dictionaries of their
+// populated fields, repeated fields as lists, maps as dictionaries, and every
+// value is tagged with its ObjectHash type. Repeated fields hashed as sets, by
+// WithUnorderedFields or (protohash.field).unordered, are ObjectHash sets of
+// their distinct elements, and those hashed as multisets are lists of their
+// elements sorted by hash. Timestamps and durations are hashed
+// as the list of their seconds and nanos, wrappers as their value, and Struct,
+// Value and ListValue like the equivalent JSON. Any is only supported when
+// WithResolver is used.
+//
+// The options that select fields or change values are honoured: WithFieldMask,
+// WithExcludePaths, (protohash.field).ignore and normalize, ExtensionsExcluded
+// and WithFloatPrecision. Floats are always canonical, as ObjectHash hashes
+// the number they denote, and -0 is treated as unset under
+// WithCanonicalFloats. The options that only apply to the uint64 hashes, such
+// as WithAlgorithm, WithTaggedEncoding or WithTypeNames, are ignored.
+// WithUnknownFields has no ObjectHash representation, and hashing with it fails
+// with an error wrapping ErrUnsupportedOption.
+func (ph *ProtoHasher) ObjectHash(msg proto.Message) ([]byte, error) {
+	if err := ph.checkObjectHashOptions(); err != nil {
+		return nil, err
+	}
+
+	m, err := ph.reflectMessage(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	var of objectField
+	if ph.includePaths != nil || ph.excludePaths != nil {
+		masks := ph.masks(m.Descriptor())
+		if masks.err != nil {
+			return nil, masks.err
+		}
+		of.include, of.exclude = masks.include, masks.exclude
+	}
+
+	h, err := ph.objectHashMessage(m, of)
+	if err != nil {
+		return nil, rootError(err, m.Descriptor())
+	}
+	return h, nil
+}
+
+// checkObjectHashOptions returns an error if the hasher has options that
+// ObjectHash cannot honour.
+func (ph *ProtoHasher) checkObjectHashOptions() error {
+	if err := ph.checkOptions(); err != nil {
+		return err
+	}
+
+	if ph.unknownFields {
+		return unsupportedOption("WithUnknownFields")
+	}
+	return nil
+}
+
+// objectField is how ObjectHash hashes the values of a field: the paths to
+// apply to their message values, and the options of the field.
+type objectField struct {
+	include, exclude *pathNode
+	precision        *FloatPrecision
+	normalize        bool
+	listMode         ListMode
+}
+
+// objectFieldFor returns how ObjectHash hashes the values of the field fd.
+func (ph *ProtoHasher) objectFieldFor(fd protoreflect.FieldDescriptor, include, exclude *pathNode) objectField {
+	return objectField{
+		include:   include,
+		exclude:   exclude,
+		precision: ph.precision(fd),
+		normalize: fieldOptions(fd).GetNormalize(),
+		listMode:  ph.listMode(fd),
+	}
+}
+
+func (ph *ProtoHasher) objectHashMessage(msg protoreflect.Message, of objectField) ([]byte, error) {
+	if h, ok, err := ph.objectHashWellKnown(msg); ok {
+		return h, err
+	}
+
+	return ph.objectHashFields(msg, of)
+}
+
+// objectHashFields hashes msg as the dictionary of its populated fields, with
+// the paths of of.
+func (ph *ProtoHasher) objectHashFields(msg protoreflect.Message, of objectField) ([]byte, error) {
+	plan := ph.plan(msg.Descriptor())
+
+	var (
+		entries [][]byte
+		e       error
+	)
+	msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		var fp fieldPlan
+		if fd.IsExtension() {
+			if ph.extensionMode == ExtensionsExcluded {
+				return true
+			}
+			fp = ph.compileField(fd)
+		} else {
+			fp = plan.fields[fd.Index()]
+		}
+
+		include, exclude, ok := selectField(of.include, of.exclude, fd)
+		if fp.ignore || !ok || fp.unset(v) {
+			return true
+		}
+
+		hk := objectHashInt(int64(fd.Number()))
+		if ph.fieldNamesAsKeys {
+			hk = objectHashUnicode(string(fd.Name()))
+		}
+
+		hv, err := ph.objectHashField(fd, v, ph.objectFieldFor(fd, include, exclude))
+		if err != nil {
+			e = fieldError(err, fd)
+			return false
+		}
+
+		entries = append(entries, append(hk, hv...))
+		return true
+	})
+	if e != nil {
+		return nil, e
+	}
+
+	return objectHashDict(entries), nil
+}
+
+func (ph *ProtoHasher) objectHashField(fd protoreflect.FieldDescriptor, v protoreflect.Value, of objectField) ([]byte, error) {
+	switch {
+	case fd.IsList():
+		return ph.objectHashList(fd, v.List(), of)
+	case fd.IsMap():
+		return ph.objectHashMap(fd, v.Map(), of)
+	default:
+		return ph.objectHashValue(fd, v, of)
+	}
+}
+
+func (ph *ProtoHasher) objectHashMap(fd protoreflect.FieldDescriptor, v protoreflect.Map, of objectField) ([]byte, error) {
+	var (
+		entries [][]byte
+		e       error
+	)
+	v.Range(func(k protoreflect.MapKey, vx protoreflect.Value) bool {
+		hk, err := ph.objectHashValue(fd.MapKey(), k.Value(), of)
+		if err != nil {
+			e = mapIndexError(err, fd, k)
+			return false
+		}
+
+		hv, err := ph.objectHashValue(fd.MapValue(), vx, of)
+		if err != nil {
+			e = mapIndexError(err, fd, k)
+			return false
+		}
+
+		entries = append(entries, append(hk, hv...))
+		return true
+	})
+	if e != nil {
+		return nil, e
+	}
+
+	return objectHashDict(entries), nil
+}
+
+func (ph *ProtoHasher) objectHashList(fd protoreflect.FieldDescriptor, v protoreflect.List, of objectField) ([]byte, error) {
+	elems := make([][]byte, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		hv, err := ph.objectHashValue(fd, v.Get(i), of)
+		if err != nil {
+			return nil, listIndexError(err, fd, i)
+		}
+		elems[i] = hv
+	}
+
+	switch of.listMode {
+	case ListSet:
+		return objectHashSet(elems), nil
+	case ListMultiset:
+		sortHashes(elems)
+	}
+	return objectHashList(elems), nil
+}
+
+func (ph *ProtoHasher) objectHashValue(fd protoreflect.FieldDescriptor, v protoreflect.Value, of objectField) ([]byte, error) {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		if v.Bool() {
+			return objectHash(boolIdentifier, []byte("1")), nil
+		}
+		return objectHash(boolIdentifier, []byte("0")), nil
+
+	case protoreflect.EnumKind:
+		return objectHashInt(int64(v.Enum())), nil
+
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed32Kind, protoreflect.Sfixed64Kind:
+		return objectHashInt(v.Int()), nil
+
+	case protoreflect.Uint32Kind, protoreflect.Uint64Kind, protoreflect.Fixed32Kind, protoreflect.Fixed64Kind:
+		return objectHash(intIdentifier, []byte(strconv.FormatUint(v.Uint(), 10))), nil
+
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		f := v.Float()
+		if of.precision != nil {
+			f = of.precision.quantize(f)
+		}
+		return objectHashFloat(f)
+
+	case protoreflect.StringKind:
+		if of.normalize {
+			return objectHashUnicode(norm.NFC.String(v.String())), nil
+		}
+		return objectHashUnicode(v.String()), nil
+
+	case protoreflect.BytesKind:
+		return objectHash(bytesIdentifier, v.Bytes()), nil
+
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return ph.objectHashMessage(v.Message(), of)
+
+	default:
+		return nil, errors.Errorf("unknown kind to hash: %s", fd.Kind())
+	}
+}
+
+func objectHash(identifier byte, b []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{identifier})
+	h.Write(b)
+	return h.Sum(nil)
+}
+
+func objectHashInt(i int64) []byte {
+	return objectHash(intIdentifier, []byte(strconv.FormatInt(i, 10)))
+}
+
+func objectHashUnicode(s string) []byte {
+	return objectHash(unicodeIdentifier, []byte(s))
+}
+
+func objectHashList(elems [][]byte) []byte {
+	return objectHash(listIdentifier, bytes.Join(elems, nil))
+}
+
+// objectHashSet hashes a set from the hashes of its elements, which are sorted
+// and deduplicated so that the result is independent of order and duplicates.
+func objectHashSet(elems [][]byte) []byte {
+	sortHashes(elems)
+	distinct := elems[:0]
+	for _, hv := range elems {
+		if len(distinct) == 0 || !bytes.Equal(hv, distinct[len(distinct)-1]) {
+			distinct = append(distinct, hv)
+		}
+	}
+	return objectHash(setIdentifier, bytes.Join(distinct, nil))
+}
+
+func sortHashes(hashes [][]byte) {
+	sort.Slice(hashes, func(i, j int) bool {
+		return bytes.Compare(hashes[i], hashes[j]) < 0
+	})
+}
+
+// objectHashDict hashes a dictionary from the concatenated key and value hashes
+// of its entries, which are sorted so that the result is independent of order.
+func objectHashDict(entries [][]byte) []byte {
+	sortHashes(entries)
+	return objectHash(mapIdentifier, bytes.Join(entries, nil))
+}
+
+func objectHashFloat(f float64) ([]byte, error) {
+	var normalized string
+	switch {
+	case math.IsInf(f, 1):
+		normalized = "Infinity"
+	case math.IsInf(f, -1):
+		normalized = "-Infinity"
+	case math.IsNaN(f):
+		normalized = "NaN"
+	default:
+		var err error
+		normalized, err = floatNormalize(f)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return objectHash(floatIdentifier, []byte(normalized)), nil
+}
+
+// floatNormalize returns the ObjectHash representation of a finite float,
+// "<sign><exponent>:<mantissa bits>".
+// Adapted from https://github.com/benlaurie/objecthash
+func floatNormalize(originalFloat float64) (string, error) {
+	// Special case 0
+	// Note that if we allowed f to end up > .5 or == 0, we'd get the same thing.
+	if originalFloat == 0 {
+		return "+0:", nil
+	}
+
+	// Sign
+	f := originalFloat
+	s := "+"
+	if f < 0 {
+		s = "-"
+		f = -f
+	}
+
+	// Exponent
+	e := 0
+	for f > 1 {
+		f /= 2
+		e++
+	}
+	for f <= .5 {
+		f *= 2
+		e--
+	}
+	s += fmt.Sprintf("%d:", e)
+
+	// Mantissa
+	if f > 1 || f <= .5 {
+		return "", errors.Errorf("could not normalize float: %f", originalFloat)
+	}
+	for f != 0 {
+		if f >= 1 {
+			s += "1"
+			f--
+		} else {
+			s += "0"
+		}
+		if f >= 1 {
+			return "", errors.Errorf("could not normalize float: %f", originalFloat)
+		}
+		if len(s) >= 1000 {
+			return "", errors.Errorf("could not normalize float: %f", originalFloat)
+		}
+		f *= 2
+	}
+	return s, nil
+}
@@ -0,0 +1,59 @@
+package protohash
+
+import (
+	protohashpb "github.com/aserto-dev/go-protohash/protohash"
+	"golang.org/x/text/unicode/norm"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// fieldOptions returns the hashing options declared on fd with
+// (protohash.field), or nil if there are none.
+func fieldOptions(fd protoreflect.FieldDescriptor) *protohashpb.FieldOptions {
+	opts, _ := proto.GetExtension(fd.Options(), protohashpb.E_Field).(*protohashpb.FieldOptions)
+	return opts
+}
+
+// messageOptions returns the hashing options declared on md with
+// (protohash.message), or nil if there are none.
+func messageOptions(md protoreflect.MessageDescriptor) *protohashpb.MessageOptions {
+	opts, _ := proto.GetExtension(md.Options(), protohashpb.E_Message).(*protohashpb.MessageOptions)
+	return opts
+}
+
+// listModeOption returns the ListMode declared with (protohash.field).unordered.
+func listModeOption(opts *protohashpb.FieldOptions) ListMode {
+	switch opts.GetUnordered() {
+	case protohashpb.ListMode_LIST_MODE_SET:
+		return ListSet
+	case protohashpb.ListMode_LIST_MODE_MULTISET:
+		return ListMultiset
+	default:
+		return ListOrdered
+	}
+}
+
+// normalizedStringHasher wraps the valueHasher of a string field declared with
+// (protohash.field).normalize, so that strings are hashed in Unicode
+// Normalization Form C. Hashers of other kinds are returned as is.
+func normalizedStringHasher(fd protoreflect.FieldDescriptor, value valueHasher) valueHasher {
+	if fd.Kind() != protoreflect.StringKind {
+		return value
+	}
+	return hashNormalizedStringValue
+}
+
+func hashNormalizedStringValue(hs *hashState, v protoreflect.Value) (uint64, error) {
+	return hs.hashString(norm.NFC.String(v.String()))
+}
+
+// hashTypeName mixes the full name of a message into its hash h, for messages
+// declared with (protohash.message).include_type_name or hashed with
+// WithTypeNames.
+func (hs *hashState) hashTypeName(name protoreflect.FullName, h uint64) (uint64, error) {
+	hn, err := hs.hashString(string(name))
+	if err != nil {
+		return 0, err
+	}
+	return hs.hashUpdateOrdered(hn, h)
+}
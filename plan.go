@@ -0,0 +1,218 @@
+package protohash
+
+import (
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// messagePlan is how the messages of a given type are hashed. It is compiled
+// once per message descriptor and cached by the hasher, so that hashing the
+// same types over and over does not inspect their descriptors again.
+type messagePlan struct {
+	// wellKnown hashes the well-known types that have their own semantics,
+	// instead of hashing their fields.
+	wellKnown func(hs *hashState, msg protoreflect.Message) (uint64, error)
+
+	// fields are the plans of the fields of the message, by field index.
+	// protoreflect.Message.Range is faster than probing every field with Has,
+	// so the populated fields are still found with Range, and then hashed with
+	// their plan.
+	fields []fieldPlan
+
+	// includeTypeName is set for messages declared with
+	// (protohash.message).include_type_name, or for every message with
+	// WithTypeNames. The generated ProtoHash methods leave the type name out,
+	// and it is mixed in by hashMessage.
+	includeTypeName bool
+
+	// reflect is set when options change how some fields are hashed, which
+	// the generated ProtoHash methods do not know about.
+	reflect bool
+}
+
+// fieldShape tells singular, repeated and map fields apart.
+type fieldShape int
+
+const (
+	singularField fieldShape = iota
+	listField
+	mapField
+)
+
+// fieldPlan is how the values of a field are hashed.
+type fieldPlan struct {
+	fd    protoreflect.FieldDescriptor
+	shape fieldShape
+
+	// value hashes the value of a singular field, the elements of a list or
+	// the values of a map, and key hashes the keys of a map.
+	key, value valueHasher
+
+	// listMode is how the elements of a list are combined.
+	listMode ListMode
+
+	// ignore is set for fields declared with (protohash.field).ignore, which
+	// are left out of the hash.
+	ignore bool
+
+	// oneof is set for the fields of a oneof, other than the synthetic oneofs
+	// of proto3 optional fields, with WithSchemaEvolution. Their values are
+	// bound to oneofNumber, so that moving a field into a oneof changes the
+	// hash.
+	oneof bool
+
+	// zeroFloat is set for the float and double fields without presence of a
+	// hasher with canonical floats, which treats them as unset when they hold
+	// -0 as it does for +0.
+	zeroFloat bool
+}
+
+// valueHasher hashes a singular value of a given kind.
+type valueHasher func(hs *hashState, v protoreflect.Value) (uint64, error)
+
+// plan returns the plan of the messages described by md, compiling it the first
+// time it is needed.
+func (ph *ProtoHasher) plan(md protoreflect.MessageDescriptor) *messagePlan {
+	if p, ok := ph.plans.Load(md); ok {
+		return p.(*messagePlan)
+	}
+
+	p, _ := ph.plans.LoadOrStore(md, ph.compilePlan(md))
+	return p.(*messagePlan)
+}
+
+func (ph *ProtoHasher) compilePlan(md protoreflect.MessageDescriptor) *messagePlan {
+	fields := md.Fields()
+	p := &messagePlan{
+		wellKnown:       ph.wellKnownHasher(md),
+		fields:          make([]fieldPlan, fields.Len()),
+		includeTypeName: ph.typeNames || messageOptions(md).GetIncludeTypeName(),
+	}
+	for i := range p.fields {
+		p.fields[i] = ph.compileField(fields.Get(i))
+		if p.fields[i].listMode != ListOrdered || p.fields[i].oneof || fieldOptions(fields.Get(i)) != nil || ph.reflectFloats(fields.Get(i)) {
+			p.reflect = true
+		}
+	}
+	return p
+}
+
+func (ph *ProtoHasher) compileField(fd protoreflect.FieldDescriptor) fieldPlan {
+	var fp fieldPlan
+	switch {
+	case fd.IsList():
+		fp = fieldPlan{fd: fd, shape: listField, value: valueHasherFor(fd), listMode: ph.listMode(fd)}
+	case fd.IsMap():
+		fp = fieldPlan{fd: fd, shape: mapField, key: valueHasherFor(fd.MapKey()), value: valueHasherFor(fd.MapValue())}
+	default:
+		fp = fieldPlan{fd: fd, shape: singularField, value: valueHasherFor(fd)}
+	}
+
+	fp.value = ph.quantizedFloatHasher(fd, fp.value)
+	fp.zeroFloat = ph.canonicalFloats && fp.shape == singularField && !fd.HasPresence() &&
+		(fd.Kind() == protoreflect.FloatKind || fd.Kind() == protoreflect.DoubleKind)
+
+	if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() {
+		fp.oneof = ph.bindOneofs
+	}
+
+	opts := fieldOptions(fd)
+	fp.ignore = opts.GetIgnore()
+	if opts.GetNormalize() {
+		if fd.IsMap() {
+			fp.key = normalizedStringHasher(fd.MapKey(), fp.key)
+			fp.value = normalizedStringHasher(fd.MapValue(), fp.value)
+		} else {
+			fp.value = normalizedStringHasher(fd, fp.value)
+		}
+	}
+	return fp
+}
+
+// unset reports whether the populated value v of the field is treated as unset.
+func (fp *fieldPlan) unset(v protoreflect.Value) bool {
+	return fp.zeroFloat && v.Float() == 0
+}
+
+// combine mixes the hash hv of the field into the message hash h.
+func (fp *fieldPlan) combine(hs *hashState, h, hv uint64) (uint64, error) {
+	if fp.oneof {
+		var err error
+		hv, err = hs.hashUpdateNumber(oneofNumber, hv)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return hs.combineField(h, fp.fd.Number(), hv)
+}
+
+func (fp *fieldPlan) hash(hs *hashState, v protoreflect.Value) (uint64, error) {
+	switch fp.shape {
+	case listField:
+		return hs.hashList(fp.fd, fp.listMode, fp.value, v.List())
+	case mapField:
+		return hs.hashMap(fp.fd, fp.key, fp.value, v.Map())
+	default:
+		return fp.value(hs, v)
+	}
+}
+
+func valueHasherFor(fd protoreflect.FieldDescriptor) valueHasher {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return hashBoolValue
+	case protoreflect.EnumKind:
+		return hashEnumValue
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed32Kind, protoreflect.Sfixed64Kind:
+		return hashIntValue
+	case protoreflect.Uint32Kind, protoreflect.Uint64Kind, protoreflect.Fixed32Kind, protoreflect.Fixed64Kind:
+		return hashUintValue
+	case protoreflect.FloatKind:
+		return hashFloat32Value
+	case protoreflect.DoubleKind:
+		return hashFloatValue
+	case protoreflect.StringKind:
+		return hashStringValue
+	case protoreflect.BytesKind:
+		return hashBytesValue
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return hashMessageValue
+	default:
+		kind := fd.Kind()
+		return func(*hashState, protoreflect.Value) (uint64, error) {
+			return 0, errors.Errorf("unknown kind to hash: %s", kind)
+		}
+	}
+}
+
+func hashBoolValue(hs *hashState, v protoreflect.Value) (uint64, error) {
+	return hs.hashBool(v.Bool())
+}
+
+func hashEnumValue(hs *hashState, v protoreflect.Value) (uint64, error) {
+	return hs.hashEnum(int32(v.Enum()))
+}
+
+func hashIntValue(hs *hashState, v protoreflect.Value) (uint64, error) {
+	return hs.hashInt(v.Int())
+}
+
+func hashUintValue(hs *hashState, v protoreflect.Value) (uint64, error) {
+	return hs.hashUint(v.Uint())
+}
+
+func hashFloatValue(hs *hashState, v protoreflect.Value) (uint64, error) {
+	return hs.hashFloat(v.Float())
+}
+
+func hashStringValue(hs *hashState, v protoreflect.Value) (uint64, error) {
+	return hs.hashString(v.String())
+}
+
+func hashBytesValue(hs *hashState, v protoreflect.Value) (uint64, error) {
+	return hs.hashBytes(v.Bytes())
+}
+
+func hashMessageValue(hs *hashState, v protoreflect.Value) (uint64, error) {
+	return hs.hashMessage(v.Message())
+}
@@ -0,0 +1,141 @@
+package protohash
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// FloatPrecision is how float and double values are quantized before they are
+// hashed, as given to WithFloatPrecision.
+type FloatPrecision struct {
+	digits int
+	step   float64
+}
+
+// SignificantDigits quantizes values to n significant decimal digits, from 1
+// to 17: v is rounded to the decimal number of n significant digits nearest to
+// its exact value, ties to even, which is then converted back to the nearest
+// double. 0.30000000000000004 hashes like 0.3 with 16 digits or less.
+func SignificantDigits(n int) FloatPrecision {
+	return FloatPrecision{digits: n}
+}
+
+// DecimalStep quantizes values to multiples of step, which must be positive and
+// finite: v is replaced by RoundToEven(v / step) * step, both operations being
+// IEEE 754 double operations rounded to nearest, ties to even. Values of more
+// than 2^53 steps are left as they are, as a double cannot tell apart their
+// multiples of step anyway.
+func DecimalStep(step float64) FloatPrecision {
+	return FloatPrecision{step: step}
+}
+
+// maxSteps is the number of steps above which values are not quantized.
+const maxSteps = 1 << 53
+
+// WithFloatPrecision quantizes the values of the float and double fields with
+// the given full names, such as "tests.api.v1.DoubleMessage.value", or of every
+// float and double value, including those of wrappers and Struct values, when
+// no name is given. Precisions given for a field override the one given for
+// every value.
+//
+// The rounding rules of SignificantDigits and DecimalStep only involve
+// correctly rounded IEEE 754 operations, so a value is quantized alike on every
+// platform. NaNs and infinities are left as they are, and values quantized to
+// zero hash as +0, but quantization does not change whether a field is
+// populated. Float values are quantized as the double they convert to, which
+// is hashed as such unless WithFloat32Bits is used.
+//
+// Messages with quantized fields are hashed through reflection rather than by
+// their generated ProtoHash method. Invalid precisions make hashing fail.
+func WithFloatPrecision(p FloatPrecision, fields ...protoreflect.FullName) HashOption {
+	return func(ph *ProtoHasher) {
+		if err := p.check(); err != nil && ph.optionsErr == nil {
+			ph.optionsErr = err
+		}
+
+		if len(fields) == 0 {
+			ph.floatPrecision = &p
+			return
+		}
+		if ph.fieldPrecisions == nil {
+			ph.fieldPrecisions = make(map[protoreflect.FullName]FloatPrecision, len(fields))
+		}
+		for _, name := range fields {
+			ph.fieldPrecisions[name] = p
+		}
+	}
+}
+
+func (p FloatPrecision) check() error {
+	switch {
+	case p.step != 0:
+		if !(p.step > 0) || math.IsInf(p.step, 1) {
+			return errors.Errorf("invalid float precision step: %v", p.step)
+		}
+	case p.digits < 1 || p.digits > 17:
+		return errors.Errorf("invalid float precision digits: %d", p.digits)
+	}
+	return nil
+}
+
+// quantize returns v quantized to the precision p.
+func (p FloatPrecision) quantize(v float64) float64 {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return v
+	}
+
+	if p.step != 0 {
+		steps := v / p.step
+		if math.Abs(steps) < maxSteps {
+			v = math.RoundToEven(steps) * p.step
+		}
+	} else {
+		var buf [32]byte
+		b := strconv.AppendFloat(buf[:0], v, 'e', p.digits-1, 64)
+		// Rounding up the largest doubles overflows, in which case they are
+		// left as they are.
+		if q, err := strconv.ParseFloat(string(b), 64); err == nil {
+			v = q
+		}
+	}
+
+	if v == 0 {
+		return 0
+	}
+	return v
+}
+
+// precision returns the precision of the values of the field fd, or nil if
+// they are not quantized.
+func (ph *ProtoHasher) precision(fd protoreflect.FieldDescriptor) *FloatPrecision {
+	if p, ok := ph.fieldPrecisions[fd.FullName()]; ok {
+		return &p
+	}
+	return ph.floatPrecision
+}
+
+// quantizedFloatHasher returns a valueHasher hashing the values of the float or
+// double field fd, or of its map values, quantized to their precision with
+// hash, or hash itself if they are not quantized.
+func (ph *ProtoHasher) quantizedFloatHasher(fd protoreflect.FieldDescriptor, hash valueHasher) valueHasher {
+	kind := fd.Kind()
+	if fd.IsMap() {
+		kind = fd.MapValue().Kind()
+	}
+	if kind != protoreflect.FloatKind && kind != protoreflect.DoubleKind {
+		return hash
+	}
+
+	p := ph.precision(fd)
+	if p == nil {
+		return hash
+	}
+
+	precision := *p
+	return func(hs *hashState, v protoreflect.Value) (uint64, error) {
+		return hash(hs, protoreflect.ValueOfFloat64(precision.quantize(v.Float())))
+	}
+}
@@ -4,133 +4,578 @@ import (
 	"encoding/binary"
 	"hash"
 	"hash/fnv"
+	"math"
+	"sync"
+	"unsafe"
 
 	"github.com/pkg/errors"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protopath"
 	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
 )
 
 func New(opts ...HashOption) *ProtoHasher {
-	ph := ProtoHasher{
-		h: fnv.New64a(),
+	ph := &ProtoHasher{
+		newHash: func() hash.Hash64 { return fnv.New64a() },
+		alg:     AlgorithmV1,
 	}
 
 	for _, opt := range opts {
-		opt(&ph)
+		opt(ph)
 	}
 
-	return &ph
+	if ph.newDigest == nil {
+		ph.newDigest = digestFactory(ph.digestSize)
+	}
+	ph.applyKey()
+
+	ph.states.New = func() interface{} {
+		return ph.newState(ph.newHash())
+	}
+	ph.digestStates.New = func() interface{} {
+		return ph.newDigestState(ph.newDigest())
+	}
+
+	return ph
 }
 
 type HashOption func(*ProtoHasher)
 
+// WithHash64 makes the hasher use h for every call. A single hash.Hash64 cannot
+// be shared, so calls to a hasher created with WithHash64 are serialized. h
+// must not be given to other hashers, nor used elsewhere, as they would not be
+// serialized with the calls of this hasher.
+//
+// Deprecated: use WithHashFactory, which lets calls run concurrently.
 func WithHash64(h hash.Hash64) HashOption {
 	return func(ph *ProtoHasher) {
-		ph.h = h
+		ph.newHash = nil
+		ph.shared = &sharedHash{h: h}
+	}
+}
+
+// WithHashFactory makes the hasher use hash.Hash64 instances created by newHash.
+// Instances are pooled and each call uses its own, so newHash must return a new
+// hash.Hash64 every time it is called.
+func WithHashFactory(newHash func() hash.Hash64) HashOption {
+	return func(ph *ProtoHasher) {
+		ph.newHash = newHash
+		ph.shared = nil
 	}
 }
 
+// Algorithm identifies the scheme used to combine field hashes into a message hash.
+type Algorithm int
+
+const (
+	// AlgorithmV1 combines the hashes of field values only. Two messages that
+	// hold equal values in different fields hash the same.
+	AlgorithmV1 Algorithm = iota + 1
+
+	// AlgorithmV2 binds every field value to its field number before combining,
+	// so equal values in different fields produce different hashes.
+	AlgorithmV2
+)
+
+// WithAlgorithm selects the hashing algorithm, AlgorithmV1 is the default.
+func WithAlgorithm(alg Algorithm) HashOption {
+	return func(ph *ProtoHasher) {
+		ph.alg = alg
+	}
+}
+
+// WithSchemaEvolution selects a mode in which hashes are stable across the
+// compatible changes that protobuf allows in a schema. It selects AlgorithmV2,
+// and leaves type names and field names out of the hashes, overriding
+// WithTypeNames and WithFieldNamesAsKeys when given after them. In this mode:
+//
+//   - Adding a field does not change the hash of the messages that leave it
+//     unset.
+//   - Renaming a field, a message or a package does not change any hash, as
+//     fields are identified by number.
+//   - Moving a field into or out of a oneof, keeping its number and type,
+//     changes the hash of the messages that set it: the value of a field in a
+//     oneof is bound to a reserved number before it is combined with its
+//     own number. Renaming the oneof does not change the hash.
+//   - Moving values into a submessage changes the hash: the submessage is
+//     hashed as a message value of the number of its field, like any other.
+//   - Changing the number of a field changes the hash of the messages that
+//     set it.
+//
+// Messages declared with (protohash.message).include_type_name still mix their
+// type name in, so renaming them changes their hash.
+func WithSchemaEvolution() HashOption {
+	return func(ph *ProtoHasher) {
+		ph.alg = AlgorithmV2
+		ph.typeNames = false
+		ph.fieldNamesAsKeys = false
+		ph.bindOneofs = true
+	}
+}
+
+// WithTaggedEncoding prefixes every scalar value with a tag identifying its
+// kind (bool, enum, integer, float, string or bytes) and with its length before
+// it is hashed. This rules out collisions between values of different kinds
+// that share the same byte representation, such as the string "\x01\x00\x00\x00\x00\x00\x00\x00"
+// and the integer 1, or the int64 -1 and the uint64 2^64-1. Signed integer
+// kinds share a tag, and so do unsigned integer kinds, and float and double.
+func WithTaggedEncoding() HashOption {
+	return func(ph *ProtoHasher) {
+		ph.taggedEncoding = true
+	}
+}
+
+// WithTypeNames mixes the full name of every message, including nested and
+// well-known ones, into its hash, as (protohash.message).include_type_name does
+// for a single message. Messages of different types holding the same fields
+// then hash differently, and an empty message no longer hashes to 0 but to a
+// non-zero sentinel bound to its type.
+func WithTypeNames() HashOption {
+	return func(ph *ProtoHasher) {
+		ph.typeNames = true
+	}
+}
+
+// WithReflectionOnly makes the hasher ignore the ProtoHash methods generated by
+// protoc-gen-go-protohash and always hash messages through protoreflect.
+func WithReflectionOnly() HashOption {
+	return func(ph *ProtoHasher) {
+		ph.reflectionOnly = true
+	}
+}
+
+// WithAllowPartial allows hashing messages with missing required fields.
+func WithAllowPartial() HashOption {
+	return func(ph *ProtoHasher) {
+		ph.allowPartial = true
+	}
+}
+
+// ProtoHasher hashes protobuf messages. It is safe for concurrent use.
 type ProtoHasher struct {
-	h hash.Hash64
+	newHash           func() hash.Hash64
+	shared            *sharedHash
+	states            sync.Pool
+	newDigest         func() hash.Hash
+	digestSize        int
+	digestStates      sync.Pool
+	key               []byte
+	listModes         map[protoreflect.FullName]ListMode
+	typeNames         bool
+	includePaths      []string
+	excludePaths      []string
+	alg               Algorithm
+	fieldNamesAsKeys  bool
+	bindOneofs        bool
+	taggedEncoding    bool
+	allowPartial      bool
+	resolver          protoregistry.MessageTypeResolver
+	unresolvedAny     UnresolvedAnyPolicy
+	reflectionOnly    bool
+	canonicalFloats   bool
+	float32Bits       bool
+	unknownFields     bool
+	extensionMode     ExtensionMode
+	extensionResolver protoregistry.ExtensionTypeResolver
+	floatPrecision    *FloatPrecision
+	fieldPrecisions   map[protoreflect.FullName]FloatPrecision
+
+	// optionsErr is the first error found in the options, which is returned
+	// by every hashing call.
+	optionsErr error
+
+	// plans caches a *messagePlan per protoreflect.MessageDescriptor.
+	plans sync.Map
+
+	// pathMasks caches the *pathMasks compiled from includePaths and
+	// excludePaths per protoreflect.MessageDescriptor.
+	pathMasks sync.Map
 }
 
-func (ph *ProtoHasher) HashMessage(msg proto.Message) (uint64, error) {
+// sharedHash is a hash.Hash64 provided through WithHash64, guarded by a mutex.
+type sharedHash struct {
+	mu sync.Mutex
+	h  hash.Hash64
+}
+
+// hashState holds the hash.Hash64 used by a single HashMessage call, along with
+// the options of the hasher it belongs to.
+//
+// The buffers it holds are reused from one call to the next, so that hashing
+// does not allocate for every field.
+type hashState struct {
+	*ProtoHasher
+	h     hash.Hash
+	state State
+
+	// h64 is h when the hash values are the uint64 sums of h. Otherwise the
+	// hash values are handles of digests stored in digests.
+	h64     hash.Hash64
+	digests digestArena
+
+	// scratch holds the encoding of a scalar value, with its tag and length.
+	scratch [1 + binary.MaxVarintLen64 + 16]byte
+
+	// fields is a stack of the populated fields of the messages being hashed,
+	// and appendField the callback that pushes a field on it.
+	fields      []fieldValue
+	appendField func(protoreflect.FieldDescriptor, protoreflect.Value) bool
+
+	// include and exclude are the paths to apply to the message being hashed.
+	include, exclude *pathNode
+
+	// elems is a stack of the element hashes of the unordered lists being
+	// hashed.
+	elems []uint64
+
+	// entries holds the progress of the map being hashed, and hashEntry the
+	// callback that hashes one of its entries.
+	entries   mapProgress
+	hashEntry func(protoreflect.MapKey, protoreflect.Value) bool
+
+	// tree is the stack of the nodes being recorded when HashTree builds a
+	// tree, and nil otherwise.
+	tree []*HashNode
+}
+
+func (ph *ProtoHasher) newState(h hash.Hash64) *hashState {
+	hs := ph.newBaseState(h)
+	hs.h64 = h
+	return hs
+}
+
+// newDigestState returns a state whose hash values are the digests of h.
+func (ph *ProtoHasher) newDigestState(h hash.Hash) *hashState {
+	hs := ph.newBaseState(h)
+	hs.digests = digestArena{size: h.Size(), b: make([]byte, h.Size())}
+	return hs
+}
+
+func (ph *ProtoHasher) newBaseState(h hash.Hash) *hashState {
+	hs := &hashState{ProtoHasher: ph, h: h}
+	hs.state.hs = hs
+	hs.appendField = func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		hs.fields = append(hs.fields, fieldValue{fd: fd, v: v})
+		return true
+	}
+	hs.hashEntry = hs.hashMapEntry
+	return hs
+}
+
+func (ph *ProtoHasher) acquireState() *hashState {
+	if ph.shared != nil {
+		ph.shared.mu.Lock()
+		return ph.newState(ph.shared.h)
+	}
+	return ph.states.Get().(*hashState)
+}
+
+func (ph *ProtoHasher) releaseState(hs *hashState) {
+	if ph.shared != nil {
+		ph.shared.mu.Unlock()
+		return
+	}
+	ph.states.Put(hs)
+}
+
+// reflectMessage validates msg before it is hashed and returns its reflection.
+func (ph *ProtoHasher) reflectMessage(msg proto.Message) (protoreflect.Message, error) {
 	if msg == nil {
-		return 0, status.Error(codes.InvalidArgument, "msg is nil")
+		return nil, ErrNilMessage
 	}
 
 	m := msg.ProtoReflect()
 	if !m.IsValid() {
-		return 0, status.Error(codes.FailedPrecondition, "msg is invalid")
+		return nil, ErrInvalidMessage
+	}
+
+	if !ph.allowPartial {
+		if err := proto.CheckInitialized(msg); err != nil {
+			return nil, invalidMessage("%v", err)
+		}
+	}
+
+	return m, nil
+}
+
+// Algorithm returns the hashing algorithm used by the hasher.
+func (ph *ProtoHasher) Algorithm() Algorithm {
+	return ph.alg
+}
+
+// HashMessage returns the hash of msg.
+//
+// Only populated fields are hashed. Proto3 scalars set to their zero value and
+// unset proto2 fields are skipped, even when they have a [default = ...]
+// annotation, while proto2 fields that are explicitly set are hashed, even to
+// their default value. Groups are hashed like nested messages. Messages with
+// missing required fields are rejected unless WithAllowPartial is used.
+//
+// Well-known types are hashed according to what they represent. Timestamps and
+// durations are hashed as normalized instants and durations, so an empty
+// Timestamp is an explicit zero. Wrappers are hashed as their value, even when
+// it is zero. Struct, Value and ListValue are hashed like the equivalent JSON.
+// Any is hashed as a plain message, unless WithResolver is used.
+//
+// The hashing options declared in the proto with (protohash.field) and
+// (protohash.message), from protohash/options.proto, are honored.
+//
+// Messages that implement Hashable are hashed by their generated ProtoHash
+// method, which gives the same result without going through protoreflect.
+func (ph *ProtoHasher) HashMessage(msg proto.Message) (uint64, error) {
+	m, err := ph.reflectMessage(msg)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := ph.checkOptions(); err != nil {
+		return 0, err
+	}
+
+	hs := ph.acquireState()
+	defer ph.releaseState(hs)
+
+	if err := hs.selectPaths(m.Descriptor()); err != nil {
+		return 0, err
+	}
+
+	h, err := hs.hashMessage(m)
+	if err != nil {
+		return 0, rootError(err, m.Descriptor())
+	}
+	return h, nil
+}
+
+// checkOptions returns an error if the hasher was created with invalid options.
+func (ph *ProtoHasher) checkOptions() error {
+	if ph.optionsErr != nil {
+		return ph.optionsErr
+	}
+
+	switch ph.alg {
+	case AlgorithmV1, AlgorithmV2:
+		return nil
+	default:
+		return errors.Errorf("unknown hash algorithm: %d", ph.alg)
+	}
+}
+
+func (hs *hashState) hashMessage(msg protoreflect.Message) (uint64, error) {
+	msg, err := hs.resolveExtensions(msg)
+	if err != nil {
+		return 0, err
+	}
+
+	plan := hs.plan(msg.Descriptor())
+	h, err := hs.hashMessageContent(plan, msg)
+	if err == nil {
+		h, err = hs.hashUnknown(h, msg.GetUnknown())
+	}
+	if err != nil || !plan.includeTypeName {
+		return h, err
+	}
+	return hs.hashTypeName(msg.Descriptor().FullName(), h)
+}
+
+// hashMessageContent hashes msg, without its type name.
+func (hs *hashState) hashMessageContent(plan *messagePlan, msg protoreflect.Message) (uint64, error) {
+	if plan.wellKnown != nil {
+		return hs.hashWellKnown(plan, msg)
+	}
+
+	if m, ok := msg.Interface().(Hashable); ok && !hs.reflectionOnly && !plan.reflect && hs.include == nil && hs.exclude == nil && hs.tree == nil {
+		h, err := m.ProtoHash(&hs.state)
+		if err == nil {
+			return h, nil
+		}
+		// Generated methods do not track the path of the field that failed,
+		// so the message is hashed again through reflection to find it.
 	}
 
-	return ph.hashMessage(m)
+	return hs.hashFields(plan, msg)
 }
 
-func (ph *ProtoHasher) hashMessage(msg protoreflect.Message) (uint64, error) {
-	var (
-		h uint64
-		e error
-	)
+// hashFields combines the hashes of the populated fields of msg, in field
+// number order.
+func (hs *hashState) hashFields(plan *messagePlan, msg protoreflect.Message) (uint64, error) {
+	// The fields of msg are pushed on hs.fields, above those of the messages
+	// that contain msg, and popped once they are hashed.
+	start := len(hs.fields)
+	msg.Range(hs.appendField)
+	fields := hs.fields[start:]
+	defer hs.popFields(start)
+
+	sortFields(fields)
+
+	// The values of each field are hashed with the paths that apply to them.
+	parentInclude, parentExclude := hs.include, hs.exclude
+
+	// Extensions are combined apart from the fields.
+	var h, ext uint64
+	var extended bool
+	for _, f := range fields {
+		fp := hs.planField(plan, f.fd)
+		include, exclude, ok := selectField(hs.include, hs.exclude, f.fd)
+		if fp.ignore || !ok || fp.unset(f.v) || hs.skipExtension(f.fd) {
+			continue
+		}
 
-	msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
-		hv, err := ph.hashField(fd, v)
+		if hs.tree != nil {
+			hs.enterNode(protopath.FieldAccess(f.fd))
+		}
+		hs.include, hs.exclude = include, exclude
+		hv, err := fp.hash(hs, f.v)
+		hs.include, hs.exclude = parentInclude, parentExclude
 		if err != nil {
-			e = err
-			return false
+			return 0, fieldError(err, f.fd)
+		}
+		if hs.tree != nil {
+			hs.leaveNode(hv)
 		}
 
-		h, err = hashUpdateOrdered(ph.h, h, hv)
+		if f.fd.IsExtension() {
+			ext, err = hs.addExtension(ext, f.fd, hv)
+			extended = true
+		} else {
+			h, err = fp.combine(hs, h, hv)
+		}
 		if err != nil {
-			e = err
-			return false
+			return 0, err
 		}
+	}
 
-		return true
-	})
-	if e != nil {
-		return 0, e
+	if extended {
+		return hs.hashExtensions(h, ext)
 	}
 	return h, nil
 }
 
-func (ph *ProtoHasher) hashField(fd protoreflect.FieldDescriptor, v protoreflect.Value) (uint64, error) {
-	switch {
-	case fd.IsList():
-		return ph.hashList(fd, v.List())
-	case fd.IsMap():
-		return ph.hashMap(fd, v.Map())
-	default:
-		return ph.hashValue(fd, v)
+// popFields pops the fields above start from hs.fields, dropping the references
+// they hold.
+func (hs *hashState) popFields(start int) {
+	for i := start; i < len(hs.fields); i++ {
+		hs.fields[i] = fieldValue{}
 	}
+	hs.fields = hs.fields[:start]
 }
 
-func (ph *ProtoHasher) hashMap(fd protoreflect.FieldDescriptor, v protoreflect.Map) (uint64, error) {
-	var (
-		h uint64
-		e error
-	)
-	v.Range(func(k protoreflect.MapKey, vx protoreflect.Value) bool {
-		hk, keyErr := ph.hashValue(fd.MapKey(), k.Value())
-		if keyErr != nil {
-			e = keyErr
-			return false
+// combineField mixes the hash hv of the field number num into the message hash h.
+func (hs *hashState) combineField(h uint64, num protoreflect.FieldNumber, hv uint64) (uint64, error) {
+	if hs.alg == AlgorithmV2 {
+		var err error
+		hv, err = hs.hashUpdateNumber(uint64(num), hv)
+		if err != nil {
+			return 0, err
 		}
+	}
 
-		hv, valErr := ph.hashValue(fd.MapValue(), vx)
-		if valErr != nil {
-			e = valErr
-			return false
-		}
+	return hs.hashUpdateOrdered(h, hv)
+}
 
-		fieldHash, err := hashUpdateOrdered(ph.h, hk, hv)
-		if err != nil {
-			e = err
-			return false
+type fieldValue struct {
+	fd protoreflect.FieldDescriptor
+	v  protoreflect.Value
+}
+
+// sortFields sorts fields by field number. protoreflect.Message.Range does not
+// guarantee any iteration order, and the fields are combined with an
+// order-sensitive hash, so the order is fixed here. Range mostly yields fields
+// in order already, which insertion sort handles in linear time.
+func sortFields(fields []fieldValue) {
+	for i := 1; i < len(fields); i++ {
+		for j := i; j > 0 && fields[j].fd.Number() < fields[j-1].fd.Number(); j-- {
+			fields[j], fields[j-1] = fields[j-1], fields[j]
 		}
+	}
+}
 
-		h = hashUpdateUnordered(h, fieldHash)
+// planField returns the plan of the field fd of a message planned by plan.
+// Extensions are not part of the plan, and are compiled on the fly.
+func (hs *hashState) planField(plan *messagePlan, fd protoreflect.FieldDescriptor) fieldPlan {
+	if fd.IsExtension() {
+		return hs.compileField(fd)
+	}
+	return plan.fields[fd.Index()]
+}
 
-		return true
-	})
-	if e != nil {
-		return 0, e
+// mapProgress is the state of hashMap while it ranges over a map.
+type mapProgress struct {
+	fd         protoreflect.FieldDescriptor
+	key, value valueHasher
+	h          uint64
+	err        error
+}
+
+func (hs *hashState) hashMap(fd protoreflect.FieldDescriptor, key, value valueHasher, v protoreflect.Map) (uint64, error) {
+	// A map value may hold another map, whose progress replaces that of this
+	// one until it is hashed.
+	outer := hs.entries
+	hs.entries = mapProgress{fd: fd, key: key, value: value}
+	v.Range(hs.hashEntry)
+	h, err := hs.entries.h, hs.entries.err
+	hs.entries = outer
+
+	if err != nil {
+		return 0, err
 	}
 
-	return hashFinishUnordered(ph.h, h)
+	return hs.hashFinishUnordered(h)
 }
 
-func (ph *ProtoHasher) hashList(fd protoreflect.FieldDescriptor, v protoreflect.List) (uint64, error) {
+func (hs *hashState) hashMapEntry(k protoreflect.MapKey, v protoreflect.Value) bool {
+	if hs.tree != nil {
+		hs.enterNode(protopath.MapIndex(k))
+	}
+
+	hk, err := hs.entries.key(hs, k.Value())
+	if err != nil {
+		hs.entries.err = mapIndexError(err, hs.entries.fd, k)
+		return false
+	}
+
+	hv, err := hs.entries.value(hs, v)
+	if err != nil {
+		hs.entries.err = mapIndexError(err, hs.entries.fd, k)
+		return false
+	}
+
+	fieldHash, err := hs.hashUpdateOrdered(hk, hv)
+	if err != nil {
+		hs.entries.err = err
+		return false
+	}
+
+	if hs.tree != nil {
+		hs.leaveNode(fieldHash)
+	}
+
+	hs.entries.h = hs.hashUpdateUnordered(hs.entries.h, fieldHash)
+	return true
+}
+
+func (hs *hashState) hashList(fd protoreflect.FieldDescriptor, mode ListMode, value valueHasher, v protoreflect.List) (uint64, error) {
+	if mode != ListOrdered {
+		start := len(hs.elems)
+		for i := 0; i < v.Len(); i++ {
+			hv, err := hs.hashElement(value, v, i)
+			if err != nil {
+				hs.elems = hs.elems[:start]
+				return 0, listIndexError(err, fd, i)
+			}
+			hs.elems = append(hs.elems, hv)
+		}
+		return hs.hashUnorderedList(mode, start)
+	}
+
 	var h uint64
 	for i := v.Len() - 1; i >= 0; i-- {
-		hv, err := ph.hashValue(fd, v.Get(i))
+		hv, err := hs.hashElement(value, v, i)
 		if err != nil {
-			return 0, err
+			return 0, listIndexError(err, fd, i)
 		}
 
-		h, err = hashUpdateOrdered(ph.h, h, hv)
+		h, err = hs.hashUpdateOrdered(h, hv)
 		if err != nil {
 			return 0, err
 		}
@@ -138,79 +583,173 @@ func (ph *ProtoHasher) hashList(fd protoreflect.FieldDescriptor, v protoreflect.
 	return h, nil
 }
 
-func (ph *ProtoHasher) hashValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) (uint64, error) {
-	switch fd.Kind() {
-	case protoreflect.BoolKind:
-		var tmp int8
-		if v.Bool() {
-			tmp = 1
-		}
-		ph.h.Reset()
-		err := binary.Write(ph.h, binary.LittleEndian, tmp)
-		return ph.h.Sum64(), err
-
-	case protoreflect.EnumKind:
-		ph.h.Reset()
-		err := binary.Write(ph.h, binary.LittleEndian, v.Enum())
-		return ph.h.Sum64(), err
-
-	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed32Kind, protoreflect.Sfixed64Kind:
-		ph.h.Reset()
-		err := binary.Write(ph.h, binary.LittleEndian, v.Int())
-		return ph.h.Sum64(), err
-
-	case protoreflect.Uint32Kind, protoreflect.Uint64Kind, protoreflect.Fixed32Kind, protoreflect.Fixed64Kind:
-		ph.h.Reset()
-		err := binary.Write(ph.h, binary.LittleEndian, v.Uint())
-		return ph.h.Sum64(), err
-
-	case protoreflect.FloatKind, protoreflect.DoubleKind:
-		ph.h.Reset()
-		err := binary.Write(ph.h, binary.LittleEndian, v.Float())
-		return ph.h.Sum64(), err
-
-	case protoreflect.StringKind:
-		ph.h.Reset()
-		_, err := ph.h.Write([]byte(v.String()))
-		return ph.h.Sum64(), err
-
-	case protoreflect.BytesKind:
-		ph.h.Reset()
-		err := binary.Write(ph.h, binary.LittleEndian, v.Bytes())
-		return ph.h.Sum64(), err
-
-	case protoreflect.MessageKind, protoreflect.GroupKind:
-		return ph.hashMessage(v.Message())
+// hashElement hashes the element i of the list v.
+func (hs *hashState) hashElement(value valueHasher, v protoreflect.List, i int) (uint64, error) {
+	if hs.tree == nil {
+		return value(hs, v.Get(i))
+	}
 
-	default:
-		return 0, errors.Errorf("unknown kind to hash: %s", fd.Kind())
+	hs.enterNode(protopath.ListIndex(i))
+	hv, err := value(hs, v.Get(i))
+	if err != nil {
+		return 0, err
+	}
+	hs.leaveNode(hv)
+	return hv, nil
+}
+
+// hashValue hashes a singular value of a field that has no compiled plan at
+// hand.
+func (hs *hashState) hashValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) (uint64, error) {
+	return valueHasherFor(fd)(hs, v)
+}
+
+func (hs *hashState) hashBool(v bool) (uint64, error) {
+	var b uint64
+	if v {
+		b = 1
+	}
+	return hs.hashFixed(boolIdentifier, 1, b)
+}
+
+func (hs *hashState) hashEnum(v int32) (uint64, error) {
+	return hs.hashFixed(enumIdentifier, 4, uint64(uint32(v)))
+}
+
+func (hs *hashState) hashInt(v int64) (uint64, error) {
+	return hs.hashFixed(intIdentifier, 8, uint64(v))
+}
+
+func (hs *hashState) hashUint(v uint64) (uint64, error) {
+	return hs.hashFixed(uintIdentifier, 8, v)
+}
+
+func (hs *hashState) hashFloat(v float64) (uint64, error) {
+	if hs.canonicalFloats {
+		v = canonicalFloat64(v)
 	}
+	return hs.hashFixed(floatIdentifier, 8, math.Float64bits(v))
+}
+
+func (hs *hashState) hashString(v string) (uint64, error) {
+	if err := hs.writeHeader(unicodeIdentifier, len(v)); err != nil {
+		return 0, err
+	}
+	_, err := hs.h.Write(stringBytes(v))
+	return hs.sum(), err
+}
+
+func (hs *hashState) hashBytes(v []byte) (uint64, error) {
+	if err := hs.writeHeader(bytesIdentifier, len(v)); err != nil {
+		return 0, err
+	}
+	_, err := hs.h.Write(v)
+	return hs.sum(), err
+}
+
+// hashFixed hashes the n low-order bytes of v, in little-endian order.
+func (hs *hashState) hashFixed(identifier byte, n int, v uint64) (uint64, error) {
+	b := hs.beginValue(identifier, n)
+	for i := 0; i < n; i++ {
+		b = append(b, byte(v>>(8*i)))
+	}
+	_, err := hs.h.Write(b)
+	return hs.sum(), err
+}
+
+// beginValue resets the hash before a scalar value of n bytes is written to it,
+// and returns hs.scratch holding the header of the value, to which the value
+// can be appended. With tagged encoding the value is prefixed with its kind
+// identifier and its length, so values of different kinds never feed the same
+// bytes to the hash. Otherwise the header is empty.
+func (hs *hashState) beginValue(identifier byte, n int) []byte {
+	hs.h.Reset()
+	if !hs.taggedEncoding {
+		return hs.scratch[:0]
+	}
+
+	hs.scratch[0] = identifier
+	l := binary.PutUvarint(hs.scratch[1:], uint64(n))
+	return hs.scratch[:1+l]
+}
+
+// writeHeader resets the hash and writes the header of a scalar value of n
+// bytes, which is then written to the hash by the caller.
+func (hs *hashState) writeHeader(identifier byte, n int) error {
+	b := hs.beginValue(identifier, n)
+	if len(b) == 0 {
+		return nil
+	}
+	_, err := hs.h.Write(b)
+	return err
+}
+
+// stringBytes returns the bytes of s without copying them. They must not be
+// modified, which io.Writer implementations, hence hashes, guarantee.
+func stringBytes(s string) []byte {
+	if s == "" {
+		return nil
+	}
+	return *(*[]byte)(unsafe.Pointer(&struct {
+		string
+		int
+	}{s, len(s)}))
 }
 
 // hashUpdateUnordered
 // Adaopted for protomsg from https://github.com/mitchellh/hashstructure
-func hashUpdateUnordered(a, b uint64) uint64 {
+func (hs *hashState) hashUpdateUnordered(a, b uint64) uint64 {
+	if hs.h64 == nil {
+		return hs.digests.xor(a, b)
+	}
 	return a ^ b
 }
 
 // hashUpdateOrdered
 // Adaopted for protomsg from https://github.com/mitchellh/hashstructure
-func hashUpdateOrdered(h hash.Hash64, a, b uint64) (uint64, error) {
+func (hs *hashState) hashUpdateOrdered(a, b uint64) (uint64, error) {
 	// For ordered updates, use a real hash function
-	h.Reset()
+	hs.h.Reset()
+
+	if hs.h64 == nil {
+		if _, err := hs.h.Write(hs.digests.get(a)); err != nil {
+			return 0, err
+		}
+		if _, err := hs.h.Write(hs.digests.get(b)); err != nil {
+			return 0, err
+		}
+		return hs.sum(), nil
+	}
 
-	// We just panic if the binary writes fail because we are writing
-	// an int64 which should never be fail-able.
-	e1 := binary.Write(h, binary.LittleEndian, a)
-	e2 := binary.Write(h, binary.LittleEndian, b)
-	if e1 != nil {
-		return 0, e1
+	buf := hs.scratch[:16]
+	binary.LittleEndian.PutUint64(buf[:8], a)
+	binary.LittleEndian.PutUint64(buf[8:], b)
+	if _, err := hs.h.Write(buf); err != nil {
+		return 0, err
 	}
-	if e2 != nil {
-		return 0, e2
+
+	return hs.sum(), nil
+}
+
+// hashUpdateNumber is hashUpdateOrdered for a field number n, which is not a
+// hash value, and a hash value b.
+func (hs *hashState) hashUpdateNumber(n, b uint64) (uint64, error) {
+	if hs.h64 != nil {
+		return hs.hashUpdateOrdered(n, b)
 	}
 
-	return h.Sum64(), nil
+	hs.h.Reset()
+
+	buf := hs.scratch[:8]
+	binary.LittleEndian.PutUint64(buf, n)
+	if _, err := hs.h.Write(buf); err != nil {
+		return 0, err
+	}
+	if _, err := hs.h.Write(hs.digests.get(b)); err != nil {
+		return 0, err
+	}
+
+	return hs.sum(), nil
 }
 
 // hashFinishUnordered
@@ -222,21 +761,26 @@ func hashUpdateOrdered(h hash.Hash64, a, b uint64) (uint64, error) {
 // hashUpdateUnordered can effectively cancel out a previous change to the hash
 // result if the same hash value appears later on. For example, consider:
 //
-//   hashUpdateUnordered(hashUpdateUnordered("A", "B"), hashUpdateUnordered("A", "C")) =
-//   H("A") ^ H("B")) ^ (H("A") ^ H("C")) =
-//   (H("A") ^ H("A")) ^ (H("B") ^ H(C)) =
-//   H(B) ^ H(C) =
-//   hashUpdateUnordered(hashUpdateUnordered("Z", "B"), hashUpdateUnordered("Z", "C"))
+//	hashUpdateUnordered(hashUpdateUnordered("A", "B"), hashUpdateUnordered("A", "C")) =
+//	H("A") ^ H("B")) ^ (H("A") ^ H("C")) =
+//	(H("A") ^ H("A")) ^ (H("B") ^ H(C)) =
+//	H(B) ^ H(C) =
+//	hashUpdateUnordered(hashUpdateUnordered("Z", "B"), hashUpdateUnordered("Z", "C"))
 //
 // hashFinishUnordered "hardens" the result, so that encountering partially
 // overlapping input data later on in a different context won't cancel out.
-func hashFinishUnordered(h hash.Hash64, a uint64) (uint64, error) {
-	h.Reset()
-
-	err := binary.Write(h, binary.LittleEndian, a)
-	if err != nil {
+func (hs *hashState) hashFinishUnordered(a uint64) (uint64, error) {
+	hs.h.Reset()
+
+	buf := hs.scratch[:8]
+	if hs.h64 == nil {
+		buf = hs.digests.get(a)
+	} else {
+		binary.LittleEndian.PutUint64(buf, a)
+	}
+	if _, err := hs.h.Write(buf); err != nil {
 		return 0, err
 	}
 
-	return h.Sum64(), nil
+	return hs.sum(), nil
 }
@@ -0,0 +1,352 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        (unknown)
+// source: protohash/options.proto
+
+package protohashpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ListMode selects how the elements of a repeated field are combined.
+type ListMode int32
+
+const (
+	// The elements are combined in order.
+	ListMode_LIST_MODE_UNKNOWN ListMode = 0
+	// The order of the elements and their duplicates are ignored.
+	ListMode_LIST_MODE_SET ListMode = 1
+	// The order of the elements is ignored, but not their duplicates.
+	ListMode_LIST_MODE_MULTISET ListMode = 2
+)
+
+// Enum value maps for ListMode.
+var (
+	ListMode_name = map[int32]string{
+		0: "LIST_MODE_UNKNOWN",
+		1: "LIST_MODE_SET",
+		2: "LIST_MODE_MULTISET",
+	}
+	ListMode_value = map[string]int32{
+		"LIST_MODE_UNKNOWN":  0,
+		"LIST_MODE_SET":      1,
+		"LIST_MODE_MULTISET": 2,
+	}
+)
+
+func (x ListMode) Enum() *ListMode {
+	p := new(ListMode)
+	*p = x
+	return p
+}
+
+func (x ListMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ListMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_protohash_options_proto_enumTypes[0].Descriptor()
+}
+
+func (ListMode) Type() protoreflect.EnumType {
+	return &file_protohash_options_proto_enumTypes[0]
+}
+
+func (x ListMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ListMode.Descriptor instead.
+func (ListMode) EnumDescriptor() ([]byte, []int) {
+	return file_protohash_options_proto_rawDescGZIP(), []int{0}
+}
+
+// FieldOptions declare how a field is hashed.
+type FieldOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// ignore leaves the field out of the hash.
+	Ignore bool `protobuf:"varint,1,opt,name=ignore,proto3" json:"ignore,omitempty"`
+	// unordered hashes a repeated field as a set or a multiset.
+	Unordered ListMode `protobuf:"varint,2,opt,name=unordered,proto3,enum=protohash.ListMode" json:"unordered,omitempty"`
+	// normalize hashes the strings of the field, including map keys and values,
+	// in Unicode Normalization Form C.
+	Normalize bool `protobuf:"varint,3,opt,name=normalize,proto3" json:"normalize,omitempty"`
+}
+
+func (x *FieldOptions) Reset() {
+	*x = FieldOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protohash_options_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FieldOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldOptions) ProtoMessage() {}
+
+func (x *FieldOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_protohash_options_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldOptions.ProtoReflect.Descriptor instead.
+func (*FieldOptions) Descriptor() ([]byte, []int) {
+	return file_protohash_options_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FieldOptions) GetIgnore() bool {
+	if x != nil {
+		return x.Ignore
+	}
+	return false
+}
+
+func (x *FieldOptions) GetUnordered() ListMode {
+	if x != nil {
+		return x.Unordered
+	}
+	return ListMode_LIST_MODE_UNKNOWN
+}
+
+func (x *FieldOptions) GetNormalize() bool {
+	if x != nil {
+		return x.Normalize
+	}
+	return false
+}
+
+// MessageOptions declare how a message is hashed.
+type MessageOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// include_type_name mixes the full name of the message into its hash, so
+	// that messages of different types holding the same fields hash differently.
+	IncludeTypeName bool `protobuf:"varint,1,opt,name=include_type_name,json=includeTypeName,proto3" json:"include_type_name,omitempty"`
+}
+
+func (x *MessageOptions) Reset() {
+	*x = MessageOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protohash_options_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MessageOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageOptions) ProtoMessage() {}
+
+func (x *MessageOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_protohash_options_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageOptions.ProtoReflect.Descriptor instead.
+func (*MessageOptions) Descriptor() ([]byte, []int) {
+	return file_protohash_options_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MessageOptions) GetIncludeTypeName() bool {
+	if x != nil {
+		return x.IncludeTypeName
+	}
+	return false
+}
+
+var file_protohash_options_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*FieldOptions)(nil),
+		Field:         56001,
+		Name:          "protohash.field",
+		Tag:           "bytes,56001,opt,name=field",
+		Filename:      "protohash/options.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: (*MessageOptions)(nil),
+		Field:         56001,
+		Name:          "protohash.message",
+		Tag:           "bytes,56001,opt,name=message",
+		Filename:      "protohash/options.proto",
+	},
+}
+
+// Extension fields to descriptorpb.FieldOptions.
+var (
+	// optional protohash.FieldOptions field = 56001;
+	E_Field = &file_protohash_options_proto_extTypes[0]
+)
+
+// Extension fields to descriptorpb.MessageOptions.
+var (
+	// optional protohash.MessageOptions message = 56001;
+	E_Message = &file_protohash_options_proto_extTypes[1]
+)
+
+var File_protohash_options_proto protoreflect.FileDescriptor
+
+var file_protohash_options_proto_rawDesc = []byte{
+	0x0a, 0x17, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x68, 0x61, 0x73, 0x68, 0x2f, 0x6f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x68, 0x61, 0x73, 0x68, 0x1a, 0x20, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x77, 0x0a, 0x0c, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x12, 0x31,
+	0x0a, 0x09, 0x75, 0x6e, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x68, 0x61, 0x73, 0x68, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x09, 0x75, 0x6e, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x65,
+	0x64, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x6f, 0x72, 0x6d, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x6e, 0x6f, 0x72, 0x6d, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x22,
+	0x3c, 0x0a, 0x0e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x2a, 0x0a, 0x11, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x74, 0x79, 0x70,
+	0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x69, 0x6e,
+	0x63, 0x6c, 0x75, 0x64, 0x65, 0x54, 0x79, 0x70, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x2a, 0x4c, 0x0a,
+	0x08, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x15, 0x0a, 0x11, 0x4c, 0x49, 0x53,
+	0x54, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00,
+	0x12, 0x11, 0x0a, 0x0d, 0x4c, 0x49, 0x53, 0x54, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x53, 0x45,
+	0x54, 0x10, 0x01, 0x12, 0x16, 0x0a, 0x12, 0x4c, 0x49, 0x53, 0x54, 0x5f, 0x4d, 0x4f, 0x44, 0x45,
+	0x5f, 0x4d, 0x55, 0x4c, 0x54, 0x49, 0x53, 0x45, 0x54, 0x10, 0x02, 0x3a, 0x4e, 0x0a, 0x05, 0x66,
+	0x69, 0x65, 0x6c, 0x64, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0xc1, 0xb5, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x68, 0x61, 0x73, 0x68, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x3a, 0x56, 0x0a, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1f, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xc1, 0xb5, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x68, 0x61, 0x73, 0x68, 0x2e, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x42, 0x3a, 0x5a, 0x38, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x61, 0x73, 0x65, 0x72, 0x74, 0x6f, 0x2d, 0x64, 0x65, 0x76, 0x2f, 0x67, 0x6f, 0x2d,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x68, 0x61, 0x73, 0x68, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x68,
+	0x61, 0x73, 0x68, 0x3b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x68, 0x61, 0x73, 0x68, 0x70, 0x62, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_protohash_options_proto_rawDescOnce sync.Once
+	file_protohash_options_proto_rawDescData = file_protohash_options_proto_rawDesc
+)
+
+func file_protohash_options_proto_rawDescGZIP() []byte {
+	file_protohash_options_proto_rawDescOnce.Do(func() {
+		file_protohash_options_proto_rawDescData = protoimpl.X.CompressGZIP(file_protohash_options_proto_rawDescData)
+	})
+	return file_protohash_options_proto_rawDescData
+}
+
+var file_protohash_options_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_protohash_options_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_protohash_options_proto_goTypes = []interface{}{
+	(ListMode)(0),                       // 0: protohash.ListMode
+	(*FieldOptions)(nil),                // 1: protohash.FieldOptions
+	(*MessageOptions)(nil),              // 2: protohash.MessageOptions
+	(*descriptorpb.FieldOptions)(nil),   // 3: google.protobuf.FieldOptions
+	(*descriptorpb.MessageOptions)(nil), // 4: google.protobuf.MessageOptions
+}
+var file_protohash_options_proto_depIdxs = []int32{
+	0, // 0: protohash.FieldOptions.unordered:type_name -> protohash.ListMode
+	3, // 1: protohash.field:extendee -> google.protobuf.FieldOptions
+	4, // 2: protohash.message:extendee -> google.protobuf.MessageOptions
+	1, // 3: protohash.field:type_name -> protohash.FieldOptions
+	2, // 4: protohash.message:type_name -> protohash.MessageOptions
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	3, // [3:5] is the sub-list for extension type_name
+	1, // [1:3] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_protohash_options_proto_init() }
+func file_protohash_options_proto_init() {
+	if File_protohash_options_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_protohash_options_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FieldOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protohash_options_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MessageOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_protohash_options_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   2,
+			NumExtensions: 2,
+			NumServices:   0,
+		},
+		GoTypes:           file_protohash_options_proto_goTypes,
+		DependencyIndexes: file_protohash_options_proto_depIdxs,
+		EnumInfos:         file_protohash_options_proto_enumTypes,
+		MessageInfos:      file_protohash_options_proto_msgTypes,
+		ExtensionInfos:    file_protohash_options_proto_extTypes,
+	}.Build()
+	File_protohash_options_proto = out.File
+	file_protohash_options_proto_rawDesc = nil
+	file_protohash_options_proto_goTypes = nil
+	file_protohash_options_proto_depIdxs = nil
+}
@@ -1,29 +1,682 @@
 package protohash_test
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
 	"hash/fnv"
+	"math"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/aserto-dev/go-protohash"
 	"github.com/aserto-dev/go-protohash/tests"
+	pb2 "github.com/aserto-dev/go-protohash/tests/api/proto2/v1"
+	"github.com/aserto-dev/go-protohash/tests/api/v1"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protopath"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func TestFunctional(t *testing.T) {
-	h := fnv.New64a()
-	ph := protohash.New(protohash.WithHash64(h))
+	// Every hasher gets its own hash, which must not be shared.
+	ph := protohash.New(protohash.WithHash64(fnv.New64a()))
+
+	// Most equivalent objects and JSON strings are keyed by field name, the
+	// oneof ones are keyed by field number.
+	phNames := protohash.New(protohash.WithHash64(fnv.New64a()), protohash.WithFieldNamesAsKeys())
+	phAny := protohash.New(protohash.WithHash64(fnv.New64a()), protohash.WithFieldNamesAsKeys(), protohash.WithResolver(protoregistry.GlobalTypes))
 
 	// t.Run("TestBadness", func(t *testing.T) { tests.TestBadness(t, ph) })
-	t.Run("TestEmptyFields", func(t *testing.T) { tests.TestEmptyFields(t, ph) })
-	t.Run("TestFloatFields", func(t *testing.T) { tests.TestFloatFields(t, ph) })
-	t.Run("TestIntegerFields", func(t *testing.T) { tests.TestIntegerFields(t, ph) })
-	t.Run("TestMaps", func(t *testing.T) { tests.TestMaps(t, ph) })
+	t.Run("TestEmptyFields", func(t *testing.T) { tests.TestEmptyFields(t, phNames) })
+	t.Run("TestFieldOrder", func(t *testing.T) { tests.TestFieldOrder(t, ph) })
+	t.Run("TestFloatFields", func(t *testing.T) { tests.TestFloatFields(t, phNames) })
+	t.Run("TestIntegerFields", func(t *testing.T) { tests.TestIntegerFields(t, phNames) })
+	t.Run("TestMaps", func(t *testing.T) { tests.TestMaps(t, phNames) })
 	t.Run("TestOneOfFields", func(t *testing.T) { tests.TestOneOfFields(t, ph) })
-	t.Run("TestOtherTypes", func(t *testing.T) { tests.TestOtherTypes(t, ph) })
-	// t.Run("TestProto2DefaultFieldValues", func(t *testing.T) { tests.TestProto2DefaultFieldValues(t, ph) })
-	t.Run("TestRepeatedFields", func(t *testing.T) { tests.TestRepeatedFields(t, ph) })
-	t.Run("TestStringFields", func(t *testing.T) { tests.TestStringFields(t, ph) })
+	t.Run("TestOtherTypes", func(t *testing.T) { tests.TestOtherTypes(t, phNames) })
+	t.Run("TestProto2DefaultFieldValues", func(t *testing.T) { tests.TestProto2DefaultFieldValues(t, phNames) })
+	t.Run("TestProto2Groups", func(t *testing.T) { tests.TestProto2Groups(t, phNames) })
+	t.Run("TestProto2RequiredFields", func(t *testing.T) { tests.TestProto2RequiredFields(t, phNames) })
+	t.Run("TestRepeatedFields", func(t *testing.T) { tests.TestRepeatedFields(t, phNames) })
+	t.Run("TestStringFields", func(t *testing.T) { tests.TestStringFields(t, phNames) })
+	t.Run("TestWireFormat", func(t *testing.T) { tests.TestWireFormat(t, ph) })
 
 	// Well-known types.
-	// t.Run("TestTimestamps", func(t *testing.T) { wkt.TestTimestamps(t, ph) })
-	// t.Run("TestUnsupportedWellKnownTypes", func(t *testing.T) { wkt.TestUnsupportedWellKnownTypes(t, ph) })
+	t.Run("TestAny", func(t *testing.T) { tests.TestAny(t, phAny) })
+	t.Run("TestTimestamps", func(t *testing.T) { tests.TestTimestamps(t, phNames) })
+	t.Run("TestUnsupportedWellKnownTypes", func(t *testing.T) { tests.TestUnsupportedWellKnownTypes(t, ph) })
+	t.Run("TestWellKnownTypes", func(t *testing.T) { tests.TestWellKnownTypes(t, phNames) })
+
+	phSet := protohash.New(protohash.WithUnorderedFields(protohash.ListSet, tests.UnorderedFields...))
+	phMultiset := protohash.New(protohash.WithUnorderedFields(protohash.ListMultiset, tests.UnorderedFields...))
+	t.Run("TestUnorderedFields", func(t *testing.T) { tests.TestUnorderedFields(t, phSet, phMultiset) })
+	t.Run("TestHashingOptions", func(t *testing.T) { tests.TestHashingOptions(t, ph) })
+	t.Run("TestTypeNames", func(t *testing.T) { tests.TestTypeNames(t, protohash.New(protohash.WithTypeNames())) })
+	t.Run("TestFieldMasks", func(t *testing.T) { tests.TestFieldMasks(t) })
+	t.Run("TestCanonicalFloats", func(t *testing.T) { tests.TestCanonicalFloats(t, protohash.New(protohash.WithCanonicalFloats())) })
+	t.Run("TestFloat32Bits", func(t *testing.T) { tests.TestFloat32Bits(t, protohash.New(protohash.WithFloat32Bits())) })
+	t.Run("TestFloatPrecision", func(t *testing.T) { tests.TestFloatPrecision(t) })
+	t.Run("TestUnknownFields", func(t *testing.T) { tests.TestUnknownFields(t, protohash.New(protohash.WithUnknownFields())) })
+	t.Run("TestExtensions", func(t *testing.T) { tests.TestExtensions(t) })
+
+	phTagged := protohash.New(protohash.WithHash64(fnv.New64a()), protohash.WithTaggedEncoding())
+	t.Run("TestTaggedEncoding", func(t *testing.T) { tests.TestTaggedEncoding(t, phTagged) })
+}
+
+func TestFunctionalV2(t *testing.T) {
+	ph := protohash.New(protohash.WithHash64(fnv.New64a()), protohash.WithAlgorithm(protohash.AlgorithmV2))
+	phNames := protohash.New(protohash.WithHash64(fnv.New64a()), protohash.WithAlgorithm(protohash.AlgorithmV2), protohash.WithFieldNamesAsKeys())
+	phAny := protohash.New(protohash.WithHash64(fnv.New64a()), protohash.WithAlgorithm(protohash.AlgorithmV2), protohash.WithFieldNamesAsKeys(),
+		protohash.WithResolver(protoregistry.GlobalTypes))
+
+	t.Run("TestEmptyFields", func(t *testing.T) { tests.TestEmptyFields(t, phNames) })
+	t.Run("TestFieldNumbers", func(t *testing.T) { tests.TestFieldNumbers(t, ph) })
+	t.Run("TestFieldOrder", func(t *testing.T) { tests.TestFieldOrder(t, ph) })
+	t.Run("TestFloatFields", func(t *testing.T) { tests.TestFloatFields(t, phNames) })
+	t.Run("TestIntegerFields", func(t *testing.T) { tests.TestIntegerFields(t, phNames) })
+	t.Run("TestMaps", func(t *testing.T) { tests.TestMaps(t, phNames) })
+	t.Run("TestOneOfFields", func(t *testing.T) { tests.TestOneOfFields(t, ph) })
+	t.Run("TestOtherTypes", func(t *testing.T) { tests.TestOtherTypes(t, phNames) })
+	t.Run("TestProto2DefaultFieldValues", func(t *testing.T) { tests.TestProto2DefaultFieldValues(t, phNames) })
+	t.Run("TestProto2Groups", func(t *testing.T) { tests.TestProto2Groups(t, phNames) })
+	t.Run("TestProto2RequiredFields", func(t *testing.T) { tests.TestProto2RequiredFields(t, phNames) })
+	t.Run("TestRepeatedFields", func(t *testing.T) { tests.TestRepeatedFields(t, phNames) })
+	t.Run("TestStringFields", func(t *testing.T) { tests.TestStringFields(t, phNames) })
+	t.Run("TestWireFormat", func(t *testing.T) { tests.TestWireFormat(t, ph) })
+
+	// Well-known types.
+	t.Run("TestAny", func(t *testing.T) { tests.TestAny(t, phAny) })
+	t.Run("TestTimestamps", func(t *testing.T) { tests.TestTimestamps(t, phNames) })
+	t.Run("TestWellKnownTypes", func(t *testing.T) { tests.TestWellKnownTypes(t, phNames) })
+
+	phSet := protohash.New(protohash.WithAlgorithm(protohash.AlgorithmV2), protohash.WithUnorderedFields(protohash.ListSet, tests.UnorderedFields...))
+	phMultiset := protohash.New(protohash.WithAlgorithm(protohash.AlgorithmV2), protohash.WithUnorderedFields(protohash.ListMultiset, tests.UnorderedFields...))
+	t.Run("TestUnorderedFields", func(t *testing.T) { tests.TestUnorderedFields(t, phSet, phMultiset) })
+	t.Run("TestHashingOptions", func(t *testing.T) { tests.TestHashingOptions(t, ph) })
+	t.Run("TestTypeNames", func(t *testing.T) {
+		tests.TestTypeNames(t, protohash.New(protohash.WithAlgorithm(protohash.AlgorithmV2), protohash.WithTypeNames()))
+	})
+	t.Run("TestFieldMasks", func(t *testing.T) { tests.TestFieldMasks(t, protohash.WithAlgorithm(protohash.AlgorithmV2)) })
+	t.Run("TestSchemaEvolution", func(t *testing.T) { tests.TestSchemaEvolution(t, protohash.New(protohash.WithSchemaEvolution())) })
+	t.Run("TestSchemaEvolutionOverride", func(t *testing.T) {
+		tests.TestSchemaEvolution(t, protohash.New(protohash.WithTypeNames(), protohash.WithSchemaEvolution()))
+	})
+	t.Run("TestCanonicalFloats", func(t *testing.T) {
+		tests.TestCanonicalFloats(t, protohash.New(protohash.WithAlgorithm(protohash.AlgorithmV2), protohash.WithCanonicalFloats()))
+	})
+	t.Run("TestFloat32Bits", func(t *testing.T) {
+		tests.TestFloat32Bits(t, protohash.New(protohash.WithAlgorithm(protohash.AlgorithmV2), protohash.WithFloat32Bits()))
+	})
+	t.Run("TestFloatPrecision", func(t *testing.T) { tests.TestFloatPrecision(t, protohash.WithAlgorithm(protohash.AlgorithmV2)) })
+	t.Run("TestUnknownFields", func(t *testing.T) {
+		tests.TestUnknownFields(t, protohash.New(protohash.WithAlgorithm(protohash.AlgorithmV2), protohash.WithUnknownFields()))
+	})
+	t.Run("TestExtensions", func(t *testing.T) { tests.TestExtensions(t, protohash.WithAlgorithm(protohash.AlgorithmV2)) })
+}
+
+func TestConcurrentHashMessage(t *testing.T) {
+	messages := []proto.Message{
+		&api.Empty{},
+		&api.Simple{BoolField: true, Int64Field: -42, StringField: "simple", DoubleField: 1.5},
+		&api.Simple{SimpleField: &api.Simple{BytesField: []byte("nested")}},
+		&api.Repetitive{StringField: []string{"a", "b", "c"}, Int32Field: []int32{1, 2, 3}},
+		&api.Singleton{Singleton: &api.Singleton_TheString{TheString: "oneof"}},
+		&api.StringMaps{StringToString: map[string]string{"foo": "bar", "baz": "qux"}},
+		&api.IntMaps{IntToSimple: map[int64]*api.Simple{1: {Uint32Field: 1}, 2: {Uint64Field: 2}}},
+		&api.MyFavoritePlanets{Planets: []api.Planet{api.Planet_PLANET_EARTH, api.Planet_PLANET_MARS}},
+	}
+
+	hashers := map[string]*protohash.ProtoHasher{
+		"default":      protohash.New(),
+		"hash factory": protohash.New(protohash.WithHashFactory(fnv.New64)),
+		"shared hash":  protohash.New(protohash.WithHash64(fnv.New64a())),
+		"tagged v2":    protohash.New(protohash.WithAlgorithm(protohash.AlgorithmV2), protohash.WithTaggedEncoding()),
+		"keyed":        protohash.New(protohash.WithKey([]byte("secret"))),
+	}
+
+	for name, ph := range hashers {
+		ph := ph
+		t.Run(name, func(t *testing.T) {
+			expected := make([]uint64, len(messages))
+			for i, msg := range messages {
+				hv, err := ph.HashMessage(msg)
+				require.NoError(t, err)
+				expected[i] = hv
+			}
+
+			const (
+				goroutines = 16
+				iterations = 200
+			)
+
+			var wg sync.WaitGroup
+			errs := make(chan error, goroutines)
+			for g := 0; g < goroutines; g++ {
+				wg.Add(1)
+				go func(g int) {
+					defer wg.Done()
+					for n := 0; n < iterations; n++ {
+						i := (g + n) % len(messages)
+						hv, err := ph.HashMessage(messages[i])
+						if err != nil {
+							errs <- err
+							return
+						}
+						if hv != expected[i] {
+							errs <- fmt.Errorf("hash of %T{ %[1]v } is %x, expected %x", messages[i], hv, expected[i])
+							return
+						}
+					}
+				}(g)
+			}
+			wg.Wait()
+			close(errs)
+
+			for err := range errs {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestAllowPartial(t *testing.T) {
+	partial := &pb2.Required{Name: proto.String("no id")}
+
+	_, err := protohash.New().HashMessage(partial)
+	require.Error(t, err)
+
+	ph := protohash.New(protohash.WithAllowPartial())
+	hv, err := ph.HashMessage(partial)
+	require.NoError(t, err)
+
+	complete, err := ph.HashMessage(&pb2.Required{Id: proto.Int32(0), Name: proto.String("no id")})
+	require.NoError(t, err)
+	require.NotEqual(t, complete, hv)
+}
+
+func TestUnresolvedAny(t *testing.T) {
+	unknown := &api.KnownTypes{AnyField: &anypb.Any{TypeUrl: "example.com/unknown.Message", Value: []byte{0x08, 0x01}}}
+	empty := &api.KnownTypes{AnyField: &anypb.Any{}}
+	resolver := new(protoregistry.Types)
+
+	_, err := protohash.New(protohash.WithResolver(resolver)).HashMessage(unknown)
+	require.Error(t, err)
+
+	_, err = protohash.New(protohash.WithResolver(resolver)).ObjectHash(unknown)
+	require.Error(t, err)
+
+	// The raw policy hashes the Any like a hasher without a resolver does.
+	raw := protohash.New(protohash.WithResolver(resolver), protohash.WithUnresolvedAny(protohash.UnresolvedAnyRaw))
+	hv, err := raw.HashMessage(unknown)
+	require.NoError(t, err)
+
+	plain, err := protohash.New().HashMessage(unknown)
+	require.NoError(t, err)
+	require.Equal(t, plain, hv)
+
+	// The skip policy hashes the Any as if it were empty.
+	skip := protohash.New(protohash.WithResolver(resolver), protohash.WithUnresolvedAny(protohash.UnresolvedAnySkip))
+	hv, err = skip.HashMessage(unknown)
+	require.NoError(t, err)
+
+	hEmpty, err := skip.HashMessage(empty)
+	require.NoError(t, err)
+	require.Equal(t, hEmpty, hv)
+
+	ov, err := skip.ObjectHash(unknown)
+	require.NoError(t, err)
+
+	oEmpty, err := skip.ObjectHash(empty)
+	require.NoError(t, err)
+	require.Equal(t, oEmpty, ov)
+
+	// A resolved Any that cannot be unpacked is an error whatever the policy.
+	invalid := &api.KnownTypes{AnyField: &anypb.Any{TypeUrl: "type.googleapis.com/tests.api.v1.Simple", Value: []byte{0xff}}}
+	skipGlobal := protohash.New(protohash.WithResolver(protoregistry.GlobalTypes), protohash.WithUnresolvedAny(protohash.UnresolvedAnySkip))
+	_, err = skipGlobal.HashMessage(invalid)
+	require.Error(t, err)
+}
+
+func TestObjectHashOptions(t *testing.T) {
+	objectHash := func(ph *protohash.ProtoHasher, msg proto.Message) []byte {
+		h, err := ph.ObjectHash(msg)
+		require.NoError(t, err)
+		return h
+	}
+	plain := protohash.New()
+
+	// Options that select fields or change values are honoured.
+	simple := &api.Simple{StringField: "a", Int64Field: 1, SimpleField: &api.Simple{BoolField: true, Int32Field: 2}}
+	masked := protohash.New(protohash.WithFieldMask(&fieldmaskpb.FieldMask{Paths: []string{"string_field", "simple_field.int32_field"}}))
+	require.Equal(t, objectHash(plain, &api.Simple{StringField: "a", SimpleField: &api.Simple{Int32Field: 2}}), objectHash(masked, simple))
+	excluded := protohash.New(protohash.WithExcludePaths("int64_field", "simple_field.bool_field"))
+	require.Equal(t, objectHash(plain, &api.Simple{StringField: "a", SimpleField: &api.Simple{Int32Field: 2}}), objectHash(excluded, simple))
+
+	require.Equal(t,
+		objectHash(plain, &api.Annotated{Id: "a", Name: "\u00e9"}),
+		objectHash(plain, &api.Annotated{Id: "a", Etag: "b", Name: "e\u0301"}))
+
+	rounded := protohash.New(protohash.WithFloatPrecision(protohash.SignificantDigits(15)))
+	require.Equal(t, objectHash(plain, &api.DoubleMessage{Value: 0.3}), objectHash(rounded, &api.DoubleMessage{Value: 0.30000000000000004}))
+	require.NotEqual(t, objectHash(plain, &api.DoubleMessage{Value: 0.3}), objectHash(plain, &api.DoubleMessage{Value: 0.30000000000000004}))
+
+	canonical := protohash.New(protohash.WithCanonicalFloats())
+	require.Equal(t, objectHash(plain, &api.DoubleMessage{}), objectHash(canonical, &api.DoubleMessage{Value: math.Copysign(0, -1)}))
+
+	noExtensions := protohash.New(protohash.WithExtensions(protohash.ExtensionsExcluded))
+	extended := &pb2.Extendable{Id: proto.Int32(1)}
+	proto.SetExtension(extended, pb2.E_Label, "a")
+	require.Equal(t, objectHash(plain, &pb2.Extendable{Id: proto.Int32(1)}), objectHash(noExtensions, extended))
+
+	// Sets are ObjectHash sets of their distinct elements, and multisets lists
+	// of their elements sorted by hash.
+	hash := func(identifier byte, parts ...[]byte) []byte {
+		h := sha256.New()
+		h.Write([]byte{identifier})
+		for _, p := range parts {
+			h.Write(p)
+		}
+		return h.Sum(nil)
+	}
+	lo, hi := hash('u', []byte("a")), hash('u', []byte("b"))
+	if bytes.Compare(lo, hi) > 0 {
+		lo, hi = hi, lo
+	}
+	require.Equal(t,
+		hash('d', hash('i', []byte("3")), hash('s', lo, hi)),
+		objectHash(plain, &api.Annotated{Tags: []string{"b", "a", "b"}}))
+	require.Equal(t,
+		hash('d', hash('i', []byte("4")), hash('l', lo, lo, hi)),
+		objectHash(plain, &api.Annotated{Labels: []string{"b", "a", "a"}}))
+	require.Equal(t,
+		objectHash(plain, &api.Annotated{Labels: []string{"a", "b", "a"}}),
+		objectHash(plain, &api.Annotated{Labels: []string{"b", "a", "a"}}))
+	require.NotEqual(t,
+		objectHash(plain, &api.Annotated{Labels: []string{"a", "b"}}),
+		objectHash(plain, &api.Annotated{Labels: []string{"a", "b", "a"}}))
+	set := protohash.New(protohash.WithUnorderedFields(protohash.ListSet, "tests.api.v1.Repetitive.string_field"))
+	require.Equal(t,
+		objectHash(set, &api.Repetitive{StringField: []string{"a", "b"}}),
+		objectHash(set, &api.Repetitive{StringField: []string{"b", "a", "a"}}))
+
+	// Options that have no ObjectHash representation are rejected, and so are
+	// invalid options.
+	_, err := protohash.New(protohash.WithUnknownFields()).ObjectHash(&api.Simple{})
+	require.ErrorIs(t, err, protohash.ErrUnsupportedOption)
+	_, err = protohash.New(protohash.WithFloatPrecision(protohash.SignificantDigits(0))).ObjectHash(&api.Simple{})
+	require.Error(t, err)
+	_, err = protohash.New(protohash.WithExcludePaths("no_field")).ObjectHash(&api.Simple{})
+	require.ErrorIs(t, err, protohash.ErrInvalidPath)
+}
+
+func TestGeneratedMethods(t *testing.T) {
+	configs := map[string][]protohash.HashOption{
+		"V1":      nil,
+		"V2":      {protohash.WithAlgorithm(protohash.AlgorithmV2)},
+		"Tagged":  {protohash.WithTaggedEncoding()},
+		"FNV128":  {protohash.WithDigestSize(16)},
+		"Keyed":   {protohash.WithKey([]byte("0123456789abcdef"))},
+		"Typed":   {protohash.WithTypeNames()},
+		"Floats":  {protohash.WithCanonicalFloats(), protohash.WithFloat32Bits()},
+		"Rounded": {protohash.WithFloatPrecision(protohash.SignificantDigits(6))},
+	}
+
+	for name, opts := range configs {
+		generated := protohash.New(opts...)
+		reflected := protohash.New(append(opts, protohash.WithReflectionOnly())...)
+		t.Run(name, func(t *testing.T) { tests.TestGeneratedMethods(t, generated, reflected) })
+	}
+}
+
+func TestHashMessageAllocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("sync.Pool drops items at random under the race detector")
+	}
+
+	hashers := map[string]*protohash.ProtoHasher{
+		"default": protohash.New(),
+		"keyed":   protohash.New(protohash.WithKey([]byte("secret"))),
+	}
+	for name, ph := range hashers {
+		for _, m := range benchmarkMessages {
+			allocs := testing.AllocsPerRun(100, func() {
+				_, _ = ph.HashMessage(m.msg)
+			})
+			require.Zero(t, allocs, "%s allocates with the %s hasher", m.name, name)
+		}
+	}
+
+	// protoreflect allocates when it converts string and bytes values, so the
+	// reflection path is checked not to allocate beyond reading the message.
+	reflection := map[string]*protohash.ProtoHasher{
+		"reflection": protohash.New(protohash.WithReflectionOnly()),
+		"tagged":     protohash.New(protohash.WithReflectionOnly(), protohash.WithTaggedEncoding()),
+	}
+	for name, ph := range reflection {
+		for _, m := range benchmarkMessages {
+			read := testing.AllocsPerRun(100, func() {
+				readMessage(m.msg.ProtoReflect())
+			})
+			allocs := testing.AllocsPerRun(100, func() {
+				_, _ = ph.HashMessage(m.msg)
+			})
+			require.LessOrEqual(t, allocs, read, fmt.Sprintf("%s allocates with the %s hasher", m.name, name))
+		}
+	}
+}
+
+// readMessage reads every value of msg through protoreflect, as hashing it does.
+func readMessage(msg protoreflect.Message) {
+	msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsList():
+			for i := 0; i < v.List().Len(); i++ {
+				readValue(fd, v.List().Get(i))
+			}
+		case fd.IsMap():
+			v.Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+				readValue(fd.MapValue(), v)
+				return true
+			})
+		default:
+			readValue(fd, v)
+		}
+		return true
+	})
+}
+
+func readValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) {
+	if fd.Message() != nil {
+		readMessage(v.Message())
+	}
+}
+
+func TestHashMessageDigest(t *testing.T) {
+	msg := &api.Simple{StringField: "foo", Int32Field: 42, SimpleField: &api.Simple{BoolField: true}}
+
+	for _, size := range []int{16, 32, 64} {
+		ph := protohash.New(protohash.WithDigestSize(size))
+		digest, err := ph.HashMessageDigest(msg)
+		require.NoError(t, err)
+		require.Len(t, digest, size)
+
+		// The digest is not affected by the digests computed in between.
+		_, err = ph.HashMessageDigest(&api.Repetitive{StringField: []string{"bar", "baz"}})
+		require.NoError(t, err)
+		again, err := ph.HashMessageDigest(msg)
+		require.NoError(t, err)
+		require.Equal(t, digest, again)
+	}
+
+	defaultDigest, err := protohash.New().HashMessageDigest(msg)
+	require.NoError(t, err)
+	sha256Digest, err := protohash.New(protohash.WithDigestFactory(sha256.New)).HashMessageDigest(msg)
+	require.NoError(t, err)
+	require.Equal(t, sha256Digest, defaultDigest)
+
+	digest, err := protohash.New(protohash.WithDigestFactory(sha512.New512_256)).HashMessageDigest(msg)
+	require.NoError(t, err)
+	require.Len(t, digest, sha512.Size256)
+	require.NotEqual(t, defaultDigest, digest)
+
+	empty, err := protohash.New().HashMessageDigest(&api.Empty{})
+	require.NoError(t, err)
+	require.Equal(t, make([]byte, sha256.Size), empty)
+
+	_, err = protohash.New(protohash.WithDigestSize(20)).HashMessageDigest(msg)
+	require.Error(t, err)
+}
+
+func TestWithKey(t *testing.T) {
+	msg := &api.Simple{StringField: "foo", Int32Field: 42, SimpleField: &api.Simple{BoolField: true}}
+	b, err := proto.Marshal(msg)
+	require.NoError(t, err)
+
+	type result struct {
+		hash   uint64
+		digest string
+	}
+	hashWith := func(opts ...protohash.HashOption) result {
+		ph := protohash.New(opts...)
+		hv, err := ph.HashMessage(msg)
+		require.NoError(t, err)
+		wire, err := ph.HashWire(msg.ProtoReflect().Descriptor(), b)
+		require.NoError(t, err)
+		require.Equal(t, hv, wire)
+		digest, err := ph.HashMessageDigest(msg)
+		require.NoError(t, err)
+		return result{hash: hv, digest: fmt.Sprintf("%x", digest)}
+	}
+
+	unkeyed := hashWith()
+	keyed := hashWith(protohash.WithKey([]byte("0123456789abcdef")))
+	require.Equal(t, keyed, hashWith(protohash.WithKey([]byte("0123456789abcdef"))))
+	require.NotEqual(t, unkeyed.hash, keyed.hash)
+	require.NotEqual(t, unkeyed.digest, keyed.digest)
+
+	// The key takes precedence over the hash.Hash64 given by other options.
+	require.Equal(t, keyed, hashWith(protohash.WithKey([]byte("0123456789abcdef")), protohash.WithHashFactory(fnv.New64)))
+	require.Equal(t, keyed, hashWith(protohash.WithHash64(fnv.New64()), protohash.WithKey([]byte("0123456789abcdef"))))
+
+	other := hashWith(protohash.WithKey([]byte("fedcba9876543210")))
+	require.NotEqual(t, keyed.hash, other.hash)
+	require.NotEqual(t, keyed.digest, other.digest)
+
+	short := hashWith(protohash.WithKey([]byte("secret")))
+	require.NotEqual(t, keyed.hash, short.hash)
+	require.NotEqual(t, short.hash, hashWith(protohash.WithKey([]byte("secret!"))).hash)
+
+	// Empty keys are rejected rather than leaving the hasher unkeyed.
+	for _, key := range [][]byte{nil, {}} {
+		ph := protohash.New(protohash.WithKey(key))
+		_, err := ph.HashMessage(msg)
+		require.Error(t, err)
+		_, err = ph.HashWire(msg.ProtoReflect().Descriptor(), b)
+		require.Error(t, err)
+		_, err = ph.HashMessageDigest(msg)
+		require.Error(t, err)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	ph := protohash.New(protohash.WithResolver(new(protoregistry.Types)))
+
+	_, err := ph.HashMessage(nil)
+	require.ErrorIs(t, err, protohash.ErrNilMessage)
+
+	_, err = ph.HashMessageDigest((*api.Simple)(nil))
+	require.ErrorIs(t, err, protohash.ErrInvalidMessage)
+
+	_, err = ph.ObjectHash(&pb2.Required{Name: proto.String("no id")})
+	require.ErrorIs(t, err, protohash.ErrInvalidMessage)
+
+	_, err = ph.HashWire((&pb2.Required{}).ProtoReflect().Descriptor(), nil)
+	require.ErrorIs(t, err, protohash.ErrInvalidMessage)
+
+	// Fields that cannot be hashed are reported with their path, whether the
+	// message is hashed through its generated method or through reflection.
+	unknown := &api.KnownTypes{AnyField: &anypb.Any{TypeUrl: "example.com/unknown.Message"}}
+	hashers := map[string]func() error{
+		"generated": func() error { _, err := ph.HashMessage(unknown); return err },
+		"reflected": func() error {
+			_, err := protohash.New(protohash.WithResolver(new(protoregistry.Types)), protohash.WithReflectionOnly()).HashMessage(unknown)
+			return err
+		},
+		"digest":     func() error { _, err := ph.HashMessageDigest(unknown); return err },
+		"objecthash": func() error { _, err := ph.ObjectHash(unknown); return err },
+	}
+	for name, hash := range hashers {
+		var fe *protohash.FieldError
+		require.ErrorAs(t, hash(), &fe, name)
+		require.Equal(t, "(tests.api.v1.KnownTypes).any_field", fe.Path.String(), name)
+		require.Equal(t, protoreflect.MessageKind, fe.Kind, name)
+		require.ErrorIs(t, fe, protoregistry.NotFound, name)
+	}
+
+	// Paths go through map keys and list indexes.
+	var b []byte
+	b = protowire.AppendTag(b, 25, protowire.BytesType)
+	b = protowire.AppendString(b, "valid")
+	b = protowire.AppendTag(b, 25, protowire.BytesType)
+	b = protowire.AppendString(b, "in\xffvalid")
+	simple := protowire.AppendTag(nil, 33, protowire.BytesType)
+	simple = protowire.AppendBytes(simple, b)
+	entry := protowire.AppendTag(nil, 1, protowire.BytesType)
+	entry = protowire.AppendString(entry, "k")
+	entry = protowire.AppendTag(entry, 2, protowire.BytesType)
+	entry = protowire.AppendBytes(entry, simple)
+	maps := protowire.AppendTag(nil, 17, protowire.BytesType)
+	maps = protowire.AppendBytes(maps, entry)
+
+	_, err = ph.HashWire((&api.StringMaps{}).ProtoReflect().Descriptor(), maps)
+	var fe *protohash.FieldError
+	require.ErrorAs(t, err, &fe)
+	require.Equal(t, `(tests.api.v1.StringMaps).string_to_simple["k"].repetitive_field.string_field[1]`, fe.Path.String())
+	require.Equal(t, protoreflect.StringKind, fe.Kind)
+}
+
+func TestHashingOptionsOverride(t *testing.T) {
+	ab := &api.Annotated{Tags: []string{"a", "b"}}
+	ba := &api.Annotated{Tags: []string{"b", "a"}}
+
+	// WithUnorderedFields overrides the mode declared in the proto.
+	ph := protohash.New(protohash.WithUnorderedFields(protohash.ListOrdered, "tests.api.v1.Annotated.tags"))
+	hab, err := ph.HashMessage(ab)
+	require.NoError(t, err)
+	hba, err := ph.HashMessage(ba)
+	require.NoError(t, err)
+	require.NotEqual(t, hab, hba)
+}
+
+func TestHashTree(t *testing.T) {
+	messages := func(name string) *api.StringMaps {
+		return &api.StringMaps{
+			StringToBool: map[string]bool{"a": true, "b": false, "c": true},
+			StringToSimple: map[string]*api.Simple{
+				"k": {RepetitiveField: &api.Repetitive{StringField: []string{"x", name, "z"}}},
+				"l": {BoolField: true},
+			},
+		}
+	}
+
+	hashers := map[string]*protohash.ProtoHasher{
+		"V1":    protohash.New(),
+		"V2":    protohash.New(protohash.WithAlgorithm(protohash.AlgorithmV2)),
+		"Typed": protohash.New(protohash.WithTypeNames()),
+	}
+	for name, ph := range hashers {
+		// The root of the tree holds the hash of the message, computed through
+		// reflection even though the message has a generated method.
+		tree, err := ph.HashTree(messages("y"))
+		require.NoError(t, err, name)
+		h, err := ph.HashMessage(messages("y"))
+		require.NoError(t, err, name)
+		require.Equal(t, h, tree.Hash, name)
+		checkTreeSteps(t, tree, tree.Step.MessageDescriptor())
+
+		require.Len(t, tree.Children, 2, name)
+		require.Equal(t, "string_to_bool", tree.Children[0].Step.FieldDescriptor().TextName(), name)
+		require.Len(t, tree.Children[0].Children, 3, name)
+		for i, key := range []string{"a", "b", "c"} {
+			require.Equal(t, key, tree.Children[0].Children[i].Step.MapIndex().String(), name)
+		}
+
+		list := tree.Children[1].Children[0].Children[0].Children[0]
+		require.Equal(t, "string_field", list.Step.FieldDescriptor().TextName(), name)
+		require.Len(t, list.Children, 3, name)
+		for i, elem := range list.Children {
+			require.Equal(t, i, elem.Step.ListIndex(), name)
+		}
+
+		// Diff finds the subtrees that changed.
+		other, err := ph.HashTree(messages("w"))
+		require.NoError(t, err, name)
+		require.Empty(t, protohash.Diff(tree, tree), name)
+
+		diff := protohash.Diff(tree, other)
+		require.Len(t, diff, 1, name)
+		require.Equal(t, `(tests.api.v1.StringMaps).string_to_simple["k"].repetitive_field.string_field[1]`, diff[0].String(), name)
+	}
+
+	// Well-known types are leaves, so that every step of the tree is one of
+	// the descriptors of the hashed messages.
+	packed, err := anypb.New(&api.Simple{StringField: "packed"})
+	require.NoError(t, err)
+	fields, err := structpb.NewStruct(map[string]interface{}{"a": []interface{}{1, "b"}, "c": map[string]interface{}{"d": true}})
+	require.NoError(t, err)
+	known := &api.KnownTypes{
+		AnyField:       packed,
+		StructField:    fields,
+		ValueField:     structpb.NewListValue(&structpb.ListValue{Values: []*structpb.Value{structpb.NewStringValue("e")}}),
+		ListValueField: &structpb.ListValue{Values: []*structpb.Value{structpb.NewBoolValue(true)}},
+		TimestampField: timestamppb.New(time.Unix(1, 0)),
+	}
+	tree, err := protohash.New(protohash.WithResolver(protoregistry.GlobalTypes)).HashTree(known)
+	require.NoError(t, err)
+	checkTreeSteps(t, tree, known.ProtoReflect().Descriptor())
+	require.Len(t, tree.Children, 5)
+	for _, node := range tree.Children {
+		require.Empty(t, node.Children, node.Step.FieldDescriptor().TextName())
+	}
+
+	wellKnown, err := protohash.New().HashTree(fields)
+	require.NoError(t, err)
+	require.Empty(t, wellKnown.Children)
+
+	// Fields left out of the hash are left out of the tree.
+	ph := protohash.New(protohash.WithExcludePaths("string_to_simple"))
+	tree, err = ph.HashTree(messages("y"))
+	require.NoError(t, err)
+	h, err := ph.HashMessage(messages("y"))
+	require.NoError(t, err)
+	require.Equal(t, h, tree.Hash)
+	require.Len(t, tree.Children, 1)
+}
+
+// checkTreeSteps checks that the steps below n, a node of a message described
+// by md, follow the descriptors of the message.
+func checkTreeSteps(t *testing.T, n *protohash.HashNode, md protoreflect.MessageDescriptor) {
+	t.Helper()
+
+	for _, c := range n.Children {
+		require.Equal(t, protopath.FieldAccessStep, c.Step.Kind())
+		fd := c.Step.FieldDescriptor()
+		require.Equal(t, md.FullName(), fd.ContainingMessage().FullName())
+
+		var elems protopath.StepKind
+		vd := fd
+		switch {
+		case fd.IsList():
+			elems = protopath.ListIndexStep
+		case fd.IsMap():
+			elems, vd = protopath.MapIndexStep, fd.MapValue()
+		default:
+			checkValueSteps(t, c, vd)
+			continue
+		}
+		for _, e := range c.Children {
+			require.Equal(t, elems, e.Step.Kind())
+			checkValueSteps(t, e, vd)
+		}
+	}
+}
+
+func checkValueSteps(t *testing.T, n *protohash.HashNode, fd protoreflect.FieldDescriptor) {
+	t.Helper()
+
+	if fd.Message() == nil {
+		require.Empty(t, n.Children)
+		return
+	}
+	checkTreeSteps(t, n, fd.Message())
 }
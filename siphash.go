@@ -0,0 +1,118 @@
+package protohash
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/bits"
+)
+
+// sipHash is a hash.Hash64 computing SipHash-2-4, as specified in
+// https://www.aumasson.jp/siphash/siphash.pdf, keyed with k0 and k1.
+type sipHash struct {
+	k0, k1         uint64
+	v0, v1, v2, v3 uint64
+
+	// x holds the nx bytes written that do not fill a block yet, and n the
+	// total number of bytes written.
+	x  [8]byte
+	nx int
+	n  uint64
+}
+
+var _ hash.Hash64 = (*sipHash)(nil)
+
+// newSipHash returns a SipHash-2-4 hash keyed with the 16 bytes of key.
+func newSipHash(key []byte) *sipHash {
+	d := &sipHash{
+		k0: binary.LittleEndian.Uint64(key[:8]),
+		k1: binary.LittleEndian.Uint64(key[8:16]),
+	}
+	d.Reset()
+	return d
+}
+
+func (d *sipHash) Reset() {
+	d.v0 = d.k0 ^ 0x736f6d6570736575
+	d.v1 = d.k1 ^ 0x646f72616e646f6d
+	d.v2 = d.k0 ^ 0x6c7967656e657261
+	d.v3 = d.k1 ^ 0x7465646279746573
+	d.nx = 0
+	d.n = 0
+}
+
+func (d *sipHash) Size() int { return 8 }
+
+func (d *sipHash) BlockSize() int { return 8 }
+
+func (d *sipHash) Write(p []byte) (int, error) {
+	n := len(p)
+	d.n += uint64(n)
+
+	if d.nx > 0 {
+		c := copy(d.x[d.nx:], p)
+		d.nx += c
+		p = p[c:]
+		if d.nx < len(d.x) {
+			return n, nil
+		}
+		d.block(binary.LittleEndian.Uint64(d.x[:]))
+		d.nx = 0
+	}
+
+	for ; len(p) >= 8; p = p[8:] {
+		d.block(binary.LittleEndian.Uint64(p))
+	}
+	d.nx = copy(d.x[:], p)
+
+	return n, nil
+}
+
+func (d *sipHash) Sum(b []byte) []byte {
+	var s [8]byte
+	binary.LittleEndian.PutUint64(s[:], d.Sum64())
+	return append(b, s[:]...)
+}
+
+func (d *sipHash) Sum64() uint64 {
+	// The last block holds the remaining bytes and the length of the input
+	// modulo 256. It is processed on a copy, so that writes may go on.
+	var last [8]byte
+	copy(last[:], d.x[:d.nx])
+	last[7] = byte(d.n)
+
+	s := *d
+	s.block(binary.LittleEndian.Uint64(last[:]))
+
+	s.v2 ^= 0xff
+	s.round()
+	s.round()
+	s.round()
+	s.round()
+
+	return s.v0 ^ s.v1 ^ s.v2 ^ s.v3
+}
+
+// block compresses the 8 byte block m, with 2 rounds.
+func (d *sipHash) block(m uint64) {
+	d.v3 ^= m
+	d.round()
+	d.round()
+	d.v0 ^= m
+}
+
+func (d *sipHash) round() {
+	d.v0 += d.v1
+	d.v1 = bits.RotateLeft64(d.v1, 13)
+	d.v1 ^= d.v0
+	d.v0 = bits.RotateLeft64(d.v0, 32)
+	d.v2 += d.v3
+	d.v3 = bits.RotateLeft64(d.v3, 16)
+	d.v3 ^= d.v2
+	d.v0 += d.v3
+	d.v3 = bits.RotateLeft64(d.v3, 21)
+	d.v3 ^= d.v0
+	d.v2 += d.v1
+	d.v1 = bits.RotateLeft64(d.v1, 17)
+	d.v1 ^= d.v2
+	d.v2 = bits.RotateLeft64(d.v2, 32)
+}
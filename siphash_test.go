@@ -0,0 +1,46 @@
+package protohash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSipHash(t *testing.T) {
+	key := make([]byte, sipHashKeySize)
+	for i := range key {
+		key[i] = byte(i)
+	}
+	msg := make([]byte, 64)
+	for i := range msg {
+		msg[i] = byte(i)
+	}
+
+	// Test vectors from the SipHash reference implementation.
+	vectors := map[int]uint64{
+		0:  0x726fdb47dd0e0e31,
+		1:  0x74f839c593dc67fd,
+		15: 0xa129ca6149be45e5,
+	}
+	d := newSipHash(key)
+	for n, expected := range vectors {
+		d.Reset()
+		_, _ = d.Write(msg[:n])
+		require.Equal(t, expected, d.Sum64(), "length %d", n)
+	}
+
+	// Writes may be split anywhere, and Sum64 does not end the input.
+	for n := 0; n <= len(msg); n++ {
+		d.Reset()
+		_, _ = d.Write(msg[:n])
+		expected := d.Sum64()
+
+		for split := 0; split <= n; split++ {
+			d.Reset()
+			_, _ = d.Write(msg[:split])
+			_ = d.Sum64()
+			_, _ = d.Write(msg[split:n])
+			require.Equal(t, expected, d.Sum64(), "length %d split at %d", n, split)
+		}
+	}
+}
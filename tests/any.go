@@ -0,0 +1,98 @@
+package tests
+
+import (
+	"testing"
+
+	ph "github.com/aserto-dev/go-protohash"
+	pb2 "github.com/aserto-dev/go-protohash/tests/api/proto2/v1"
+	"github.com/aserto-dev/go-protohash/tests/api/v1"
+	ti "github.com/aserto-dev/go-protohash/tests/internal"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// TestAny performs tests on how google.protobuf.Any is unpacked. The hasher
+// must be able to resolve the messages of tests/api.
+func TestAny(t *testing.T, hasher *ph.ProtoHasher) {
+
+	// The same Simple message, serialized with its fields in reverse order.
+	var reversed []byte
+	reversed = protowire.AppendTag(reversed, 25, protowire.BytesType)
+	reversed = protowire.AppendString(reversed, "foo")
+	reversed = protowire.AppendTag(reversed, 15, protowire.VarintType)
+	reversed = protowire.AppendVarint(reversed, 1)
+
+	testCases := []ti.TestCase{
+		// An Any is hashed as the message it holds, however it was serialized.
+		{
+			Protos: []proto.Message{
+				mustAny(t, &api.Simple{Int64Field: 1, StringField: "foo"}),
+				&anypb.Any{TypeUrl: "type.googleapis.com/tests.api.v1.Simple", Value: reversed},
+				&anypb.Any{TypeUrl: "example.com/tests.api.v1.Simple", Value: reversed},
+			},
+			EquivalentObject: []interface{}{
+				"tests.api.v1.Simple",
+				map[string]interface{}{"int64_field": 1, "string_field": "foo"},
+			},
+			ExpectedHashString:       "e45158aa3c80041e",
+			ExpectedHashStringV2:     "aca6db9cd47baba1",
+			ExpectedObjectHashString: "def5a1ea7cd500181d2e94ebd2609e645ce43ceeca5520981f4dd2264c93c790",
+		},
+
+		{
+			Protos: []proto.Message{
+				&api.KnownTypes{AnyField: mustAny(t, &api.Empty{})},
+			},
+			EquivalentObject: map[string]interface{}{
+				"any_field": []interface{}{"tests.api.v1.Empty", map[string]interface{}{}},
+			},
+			ExpectedHashString:       "fc6746831b39c3c3",
+			ExpectedHashStringV2:     "58438ac7a2dead8",
+			ExpectedObjectHashString: "eca5085bb58f33736aa6f03ce64d68bab5c61bfcc8500be4d9ff36fc154af9b3",
+		},
+
+		// An empty Any holds no message.
+		{
+			Protos: []proto.Message{
+				&anypb.Any{},
+			},
+			EquivalentJSONString:     "{}",
+			ExpectedHashString:       "0",
+			ExpectedHashStringV2:     "0",
+			ExpectedObjectHashString: "18ac3e7343f016890c510e93f935261169d9e3f565436429830faf0934f4f8e4",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc.Check(t, hasher)
+	}
+
+	distinctCases := [][]proto.Message{
+		// The full name of the message is part of the hash.
+		{
+			&anypb.Any{},
+			mustAny(t, &api.Empty{}),
+			mustAny(t, &pb2.Empty{}),
+		},
+
+		{
+			mustAny(t, &api.Simple{Int32Field: 1}),
+			&api.Simple{Int32Field: 1},
+		},
+	}
+
+	for _, protos := range distinctCases {
+		ti.CheckDistinct(t, hasher, protos...)
+	}
+}
+
+func mustAny(t *testing.T, m proto.Message) *anypb.Any {
+	t.Helper()
+
+	a, err := anypb.New(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
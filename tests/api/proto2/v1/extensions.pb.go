@@ -0,0 +1,371 @@
+// This is used for tests that involve extensions.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        (unknown)
+// source: tests/api/proto2/v1/extensions.proto
+
+package api
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Extendable struct {
+	state           protoimpl.MessageState
+	sizeCache       protoimpl.SizeCache
+	unknownFields   protoimpl.UnknownFields
+	extensionFields protoimpl.ExtensionFields
+
+	Id    *int32      `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	Name  *string     `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	Child *Extendable `protobuf:"bytes,3,opt,name=child" json:"child,omitempty"`
+}
+
+func (x *Extendable) Reset() {
+	*x = Extendable{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tests_api_proto2_v1_extensions_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Extendable) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Extendable) ProtoMessage() {}
+
+func (x *Extendable) ProtoReflect() protoreflect.Message {
+	mi := &file_tests_api_proto2_v1_extensions_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Extendable.ProtoReflect.Descriptor instead.
+func (*Extendable) Descriptor() ([]byte, []int) {
+	return file_tests_api_proto2_v1_extensions_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Extendable) GetId() int32 {
+	if x != nil && x.Id != nil {
+		return *x.Id
+	}
+	return 0
+}
+
+func (x *Extendable) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *Extendable) GetChild() *Extendable {
+	if x != nil {
+		return x.Child
+	}
+	return nil
+}
+
+type Detail struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Text *string `protobuf:"bytes,1,opt,name=text" json:"text,omitempty"`
+}
+
+func (x *Detail) Reset() {
+	*x = Detail{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tests_api_proto2_v1_extensions_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Detail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Detail) ProtoMessage() {}
+
+func (x *Detail) ProtoReflect() protoreflect.Message {
+	mi := &file_tests_api_proto2_v1_extensions_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Detail.ProtoReflect.Descriptor instead.
+func (*Detail) Descriptor() ([]byte, []int) {
+	return file_tests_api_proto2_v1_extensions_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Detail) GetText() string {
+	if x != nil && x.Text != nil {
+		return *x.Text
+	}
+	return ""
+}
+
+// Scope declares extensions nested in a message, whose full names are
+// tests.api.proto2.v1.Scope.*.
+type Scope struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *Scope) Reset() {
+	*x = Scope{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tests_api_proto2_v1_extensions_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Scope) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Scope) ProtoMessage() {}
+
+func (x *Scope) ProtoReflect() protoreflect.Message {
+	mi := &file_tests_api_proto2_v1_extensions_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Scope.ProtoReflect.Descriptor instead.
+func (*Scope) Descriptor() ([]byte, []int) {
+	return file_tests_api_proto2_v1_extensions_proto_rawDescGZIP(), []int{2}
+}
+
+var file_tests_api_proto2_v1_extensions_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*Extendable)(nil),
+		ExtensionType: (*int32)(nil),
+		Field:         100,
+		Name:          "tests.api.proto2.v1.count",
+		Tag:           "varint,100,opt,name=count",
+		Filename:      "tests/api/proto2/v1/extensions.proto",
+	},
+	{
+		ExtendedType:  (*Extendable)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         101,
+		Name:          "tests.api.proto2.v1.label",
+		Tag:           "bytes,101,opt,name=label",
+		Filename:      "tests/api/proto2/v1/extensions.proto",
+	},
+	{
+		ExtendedType:  (*Extendable)(nil),
+		ExtensionType: ([]string)(nil),
+		Field:         102,
+		Name:          "tests.api.proto2.v1.tags",
+		Tag:           "bytes,102,rep,name=tags",
+		Filename:      "tests/api/proto2/v1/extensions.proto",
+	},
+	{
+		ExtendedType:  (*Extendable)(nil),
+		ExtensionType: (*Detail)(nil),
+		Field:         103,
+		Name:          "tests.api.proto2.v1.detail",
+		Tag:           "bytes,103,opt,name=detail",
+		Filename:      "tests/api/proto2/v1/extensions.proto",
+	},
+	{
+		ExtendedType:  (*Extendable)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         110,
+		Name:          "tests.api.proto2.v1.Scope.label",
+		Tag:           "bytes,110,opt,name=label",
+		Filename:      "tests/api/proto2/v1/extensions.proto",
+	},
+}
+
+// Extension fields to Extendable.
+var (
+	// optional int32 count = 100;
+	E_Count = &file_tests_api_proto2_v1_extensions_proto_extTypes[0]
+	// optional string label = 101;
+	E_Label = &file_tests_api_proto2_v1_extensions_proto_extTypes[1]
+	// repeated string tags = 102;
+	E_Tags = &file_tests_api_proto2_v1_extensions_proto_extTypes[2]
+	// optional tests.api.proto2.v1.Detail detail = 103;
+	E_Detail = &file_tests_api_proto2_v1_extensions_proto_extTypes[3]
+	// optional string label = 110;
+	E_Scope_Label = &file_tests_api_proto2_v1_extensions_proto_extTypes[4]
+)
+
+var File_tests_api_proto2_v1_extensions_proto protoreflect.FileDescriptor
+
+var file_tests_api_proto2_v1_extensions_proto_rawDesc = []byte{
+	0x0a, 0x24, 0x74, 0x65, 0x73, 0x74, 0x73, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x32, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x13, 0x74, 0x65, 0x73, 0x74, 0x73, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0x2e, 0x76, 0x31, 0x22, 0x6e, 0x0a, 0x0a, 0x45,
+	0x78, 0x74, 0x65, 0x6e, 0x64, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x35, 0x0a,
+	0x05, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x74,
+	0x65, 0x73, 0x74, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0x2e,
+	0x76, 0x31, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x05, 0x63,
+	0x68, 0x69, 0x6c, 0x64, 0x2a, 0x05, 0x08, 0x64, 0x10, 0xc8, 0x01, 0x22, 0x1c, 0x0a, 0x06, 0x44,
+	0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x22, 0x3e, 0x0a, 0x05, 0x53, 0x63, 0x6f,
+	0x70, 0x65, 0x32, 0x35, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x1f, 0x2e, 0x74, 0x65,
+	0x73, 0x74, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0x2e, 0x76,
+	0x31, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x6e, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x3a, 0x35, 0x0a, 0x05, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x1f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x32, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x61,
+	0x62, 0x6c, 0x65, 0x18, 0x64, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x3a, 0x35, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x1f, 0x2e, 0x74, 0x65, 0x73, 0x74,
+	0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0x2e, 0x76, 0x31, 0x2e,
+	0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x65, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x3a, 0x33, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x12,
+	0x1f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x32, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x61, 0x62, 0x6c, 0x65,
+	0x18, 0x66, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x3a, 0x54, 0x0a, 0x06,
+	0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x1f, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x74,
+	0x65, 0x6e, 0x64, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x67, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x74, 0x65, 0x73, 0x74, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32,
+	0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52, 0x06, 0x64, 0x65, 0x74, 0x61,
+	0x69, 0x6c, 0x42, 0x39, 0x5a, 0x37, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x61, 0x73, 0x65, 0x72, 0x74, 0x6f, 0x2d, 0x64, 0x65, 0x76, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x68, 0x61, 0x73, 0x68, 0x2f, 0x74, 0x65, 0x73, 0x74, 0x73, 0x2f, 0x61, 0x70, 0x69, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0x2f, 0x76, 0x31, 0x3b, 0x61, 0x70, 0x69,
+}
+
+var (
+	file_tests_api_proto2_v1_extensions_proto_rawDescOnce sync.Once
+	file_tests_api_proto2_v1_extensions_proto_rawDescData = file_tests_api_proto2_v1_extensions_proto_rawDesc
+)
+
+func file_tests_api_proto2_v1_extensions_proto_rawDescGZIP() []byte {
+	file_tests_api_proto2_v1_extensions_proto_rawDescOnce.Do(func() {
+		file_tests_api_proto2_v1_extensions_proto_rawDescData = protoimpl.X.CompressGZIP(file_tests_api_proto2_v1_extensions_proto_rawDescData)
+	})
+	return file_tests_api_proto2_v1_extensions_proto_rawDescData
+}
+
+var file_tests_api_proto2_v1_extensions_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_tests_api_proto2_v1_extensions_proto_goTypes = []interface{}{
+	(*Extendable)(nil), // 0: tests.api.proto2.v1.Extendable
+	(*Detail)(nil),     // 1: tests.api.proto2.v1.Detail
+	(*Scope)(nil),      // 2: tests.api.proto2.v1.Scope
+}
+var file_tests_api_proto2_v1_extensions_proto_depIdxs = []int32{
+	0, // 0: tests.api.proto2.v1.Extendable.child:type_name -> tests.api.proto2.v1.Extendable
+	0, // 1: tests.api.proto2.v1.count:extendee -> tests.api.proto2.v1.Extendable
+	0, // 2: tests.api.proto2.v1.label:extendee -> tests.api.proto2.v1.Extendable
+	0, // 3: tests.api.proto2.v1.tags:extendee -> tests.api.proto2.v1.Extendable
+	0, // 4: tests.api.proto2.v1.detail:extendee -> tests.api.proto2.v1.Extendable
+	0, // 5: tests.api.proto2.v1.Scope.label:extendee -> tests.api.proto2.v1.Extendable
+	1, // 6: tests.api.proto2.v1.detail:type_name -> tests.api.proto2.v1.Detail
+	7, // [7:7] is the sub-list for method output_type
+	7, // [7:7] is the sub-list for method input_type
+	6, // [6:7] is the sub-list for extension type_name
+	1, // [1:6] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_tests_api_proto2_v1_extensions_proto_init() }
+func file_tests_api_proto2_v1_extensions_proto_init() {
+	if File_tests_api_proto2_v1_extensions_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_tests_api_proto2_v1_extensions_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Extendable); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			case 3:
+				return &v.extensionFields
+			default:
+				return nil
+			}
+		}
+		file_tests_api_proto2_v1_extensions_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Detail); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tests_api_proto2_v1_extensions_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Scope); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_tests_api_proto2_v1_extensions_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 5,
+			NumServices:   0,
+		},
+		GoTypes:           file_tests_api_proto2_v1_extensions_proto_goTypes,
+		DependencyIndexes: file_tests_api_proto2_v1_extensions_proto_depIdxs,
+		MessageInfos:      file_tests_api_proto2_v1_extensions_proto_msgTypes,
+		ExtensionInfos:    file_tests_api_proto2_v1_extensions_proto_extTypes,
+	}.Build()
+	File_tests_api_proto2_v1_extensions_proto = out.File
+	file_tests_api_proto2_v1_extensions_proto_rawDesc = nil
+	file_tests_api_proto2_v1_extensions_proto_goTypes = nil
+	file_tests_api_proto2_v1_extensions_proto_depIdxs = nil
+}
@@ -0,0 +1,1098 @@
+// This is used for tests that involve proto2 explicit presence, default values,
+// required fields and groups.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        (unknown)
+// source: tests/api/proto2/v1/simple.proto
+
+package api
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Color int32
+
+const (
+	Color_COLOR_UNKNOWN Color = 0
+	Color_COLOR_RED     Color = 1
+	Color_COLOR_GREEN   Color = 2
+)
+
+// Enum value maps for Color.
+var (
+	Color_name = map[int32]string{
+		0: "COLOR_UNKNOWN",
+		1: "COLOR_RED",
+		2: "COLOR_GREEN",
+	}
+	Color_value = map[string]int32{
+		"COLOR_UNKNOWN": 0,
+		"COLOR_RED":     1,
+		"COLOR_GREEN":   2,
+	}
+)
+
+func (x Color) Enum() *Color {
+	p := new(Color)
+	*p = x
+	return p
+}
+
+func (x Color) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Color) Descriptor() protoreflect.EnumDescriptor {
+	return file_tests_api_proto2_v1_simple_proto_enumTypes[0].Descriptor()
+}
+
+func (Color) Type() protoreflect.EnumType {
+	return &file_tests_api_proto2_v1_simple_proto_enumTypes[0]
+}
+
+func (x Color) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Do not use.
+func (x *Color) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
+	if err != nil {
+		return err
+	}
+	*x = Color(num)
+	return nil
+}
+
+// Deprecated: Use Color.Descriptor instead.
+func (Color) EnumDescriptor() ([]byte, []int) {
+	return file_tests_api_proto2_v1_simple_proto_rawDescGZIP(), []int{0}
+}
+
+type Empty struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *Empty) Reset() {
+	*x = Empty{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tests_api_proto2_v1_simple_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Empty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Empty) ProtoMessage() {}
+
+func (x *Empty) ProtoReflect() protoreflect.Message {
+	mi := &file_tests_api_proto2_v1_simple_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
+func (*Empty) Descriptor() ([]byte, []int) {
+	return file_tests_api_proto2_v1_simple_proto_rawDescGZIP(), []int{0}
+}
+
+type Simple struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BoolField     *bool    `protobuf:"varint,1,opt,name=bool_field,json=boolField" json:"bool_field,omitempty"`
+	BytesField    []byte   `protobuf:"bytes,3,opt,name=bytes_field,json=bytesField" json:"bytes_field,omitempty"`
+	DoubleField   *float64 `protobuf:"fixed64,5,opt,name=double_field,json=doubleField" json:"double_field,omitempty"`
+	Fixed32Field  *uint32  `protobuf:"fixed32,7,opt,name=fixed32_field,json=fixed32Field" json:"fixed32_field,omitempty"`
+	Fixed64Field  *uint64  `protobuf:"fixed64,9,opt,name=fixed64_field,json=fixed64Field" json:"fixed64_field,omitempty"`
+	FloatField    *float32 `protobuf:"fixed32,11,opt,name=float_field,json=floatField" json:"float_field,omitempty"`
+	Int32Field    *int32   `protobuf:"varint,13,opt,name=int32_field,json=int32Field" json:"int32_field,omitempty"`
+	Int64Field    *int64   `protobuf:"varint,15,opt,name=int64_field,json=int64Field" json:"int64_field,omitempty"`
+	Sfixed32Field *int32   `protobuf:"fixed32,17,opt,name=sfixed32_field,json=sfixed32Field" json:"sfixed32_field,omitempty"`
+	Sfixed64Field *int64   `protobuf:"fixed64,19,opt,name=sfixed64_field,json=sfixed64Field" json:"sfixed64_field,omitempty"`
+	Sint32Field   *int32   `protobuf:"zigzag32,21,opt,name=sint32_field,json=sint32Field" json:"sint32_field,omitempty"`
+	Sint64Field   *int64   `protobuf:"zigzag64,23,opt,name=sint64_field,json=sint64Field" json:"sint64_field,omitempty"`
+	StringField   *string  `protobuf:"bytes,25,opt,name=string_field,json=stringField" json:"string_field,omitempty"`
+	Uint32Field   *uint32  `protobuf:"varint,27,opt,name=uint32_field,json=uint32Field" json:"uint32_field,omitempty"`
+	Uint64Field   *uint64  `protobuf:"varint,29,opt,name=uint64_field,json=uint64Field" json:"uint64_field,omitempty"`
+	SimpleField   *Simple  `protobuf:"bytes,31,opt,name=simple_field,json=simpleField" json:"simple_field,omitempty"`
+	ColorField    *Color   `protobuf:"varint,37,opt,name=color_field,json=colorField,enum=tests.api.proto2.v1.Color" json:"color_field,omitempty"`
+}
+
+func (x *Simple) Reset() {
+	*x = Simple{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tests_api_proto2_v1_simple_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Simple) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Simple) ProtoMessage() {}
+
+func (x *Simple) ProtoReflect() protoreflect.Message {
+	mi := &file_tests_api_proto2_v1_simple_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Simple.ProtoReflect.Descriptor instead.
+func (*Simple) Descriptor() ([]byte, []int) {
+	return file_tests_api_proto2_v1_simple_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Simple) GetBoolField() bool {
+	if x != nil && x.BoolField != nil {
+		return *x.BoolField
+	}
+	return false
+}
+
+func (x *Simple) GetBytesField() []byte {
+	if x != nil {
+		return x.BytesField
+	}
+	return nil
+}
+
+func (x *Simple) GetDoubleField() float64 {
+	if x != nil && x.DoubleField != nil {
+		return *x.DoubleField
+	}
+	return 0
+}
+
+func (x *Simple) GetFixed32Field() uint32 {
+	if x != nil && x.Fixed32Field != nil {
+		return *x.Fixed32Field
+	}
+	return 0
+}
+
+func (x *Simple) GetFixed64Field() uint64 {
+	if x != nil && x.Fixed64Field != nil {
+		return *x.Fixed64Field
+	}
+	return 0
+}
+
+func (x *Simple) GetFloatField() float32 {
+	if x != nil && x.FloatField != nil {
+		return *x.FloatField
+	}
+	return 0
+}
+
+func (x *Simple) GetInt32Field() int32 {
+	if x != nil && x.Int32Field != nil {
+		return *x.Int32Field
+	}
+	return 0
+}
+
+func (x *Simple) GetInt64Field() int64 {
+	if x != nil && x.Int64Field != nil {
+		return *x.Int64Field
+	}
+	return 0
+}
+
+func (x *Simple) GetSfixed32Field() int32 {
+	if x != nil && x.Sfixed32Field != nil {
+		return *x.Sfixed32Field
+	}
+	return 0
+}
+
+func (x *Simple) GetSfixed64Field() int64 {
+	if x != nil && x.Sfixed64Field != nil {
+		return *x.Sfixed64Field
+	}
+	return 0
+}
+
+func (x *Simple) GetSint32Field() int32 {
+	if x != nil && x.Sint32Field != nil {
+		return *x.Sint32Field
+	}
+	return 0
+}
+
+func (x *Simple) GetSint64Field() int64 {
+	if x != nil && x.Sint64Field != nil {
+		return *x.Sint64Field
+	}
+	return 0
+}
+
+func (x *Simple) GetStringField() string {
+	if x != nil && x.StringField != nil {
+		return *x.StringField
+	}
+	return ""
+}
+
+func (x *Simple) GetUint32Field() uint32 {
+	if x != nil && x.Uint32Field != nil {
+		return *x.Uint32Field
+	}
+	return 0
+}
+
+func (x *Simple) GetUint64Field() uint64 {
+	if x != nil && x.Uint64Field != nil {
+		return *x.Uint64Field
+	}
+	return 0
+}
+
+func (x *Simple) GetSimpleField() *Simple {
+	if x != nil {
+		return x.SimpleField
+	}
+	return nil
+}
+
+func (x *Simple) GetColorField() Color {
+	if x != nil && x.ColorField != nil {
+		return *x.ColorField
+	}
+	return Color_COLOR_UNKNOWN
+}
+
+// Defaults has the same fields as Simple, annotated with default values.
+type Defaults struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BoolField   *bool    `protobuf:"varint,1,opt,name=bool_field,json=boolField,def=1" json:"bool_field,omitempty"`
+	BytesField  []byte   `protobuf:"bytes,3,opt,name=bytes_field,json=bytesField,def=bytes" json:"bytes_field,omitempty"`
+	DoubleField *float64 `protobuf:"fixed64,5,opt,name=double_field,json=doubleField,def=1.5" json:"double_field,omitempty"`
+	Int32Field  *int32   `protobuf:"varint,13,opt,name=int32_field,json=int32Field,def=-7" json:"int32_field,omitempty"`
+	Int64Field  *int64   `protobuf:"varint,15,opt,name=int64_field,json=int64Field,def=64" json:"int64_field,omitempty"`
+	StringField *string  `protobuf:"bytes,25,opt,name=string_field,json=stringField,def=default" json:"string_field,omitempty"`
+	Uint32Field *uint32  `protobuf:"varint,27,opt,name=uint32_field,json=uint32Field,def=32" json:"uint32_field,omitempty"`
+	ColorField  *Color   `protobuf:"varint,37,opt,name=color_field,json=colorField,enum=tests.api.proto2.v1.Color,def=2" json:"color_field,omitempty"`
+}
+
+// Default values for Defaults fields.
+const (
+	Default_Defaults_BoolField   = bool(true)
+	Default_Defaults_DoubleField = float64(1.5)
+	Default_Defaults_Int32Field  = int32(-7)
+	Default_Defaults_Int64Field  = int64(64)
+	Default_Defaults_StringField = string("default")
+	Default_Defaults_Uint32Field = uint32(32)
+	Default_Defaults_ColorField  = Color_COLOR_GREEN
+)
+
+// Default values for Defaults fields.
+var (
+	Default_Defaults_BytesField = []byte("bytes")
+)
+
+func (x *Defaults) Reset() {
+	*x = Defaults{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tests_api_proto2_v1_simple_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Defaults) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Defaults) ProtoMessage() {}
+
+func (x *Defaults) ProtoReflect() protoreflect.Message {
+	mi := &file_tests_api_proto2_v1_simple_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Defaults.ProtoReflect.Descriptor instead.
+func (*Defaults) Descriptor() ([]byte, []int) {
+	return file_tests_api_proto2_v1_simple_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Defaults) GetBoolField() bool {
+	if x != nil && x.BoolField != nil {
+		return *x.BoolField
+	}
+	return Default_Defaults_BoolField
+}
+
+func (x *Defaults) GetBytesField() []byte {
+	if x != nil && x.BytesField != nil {
+		return x.BytesField
+	}
+	return append([]byte(nil), Default_Defaults_BytesField...)
+}
+
+func (x *Defaults) GetDoubleField() float64 {
+	if x != nil && x.DoubleField != nil {
+		return *x.DoubleField
+	}
+	return Default_Defaults_DoubleField
+}
+
+func (x *Defaults) GetInt32Field() int32 {
+	if x != nil && x.Int32Field != nil {
+		return *x.Int32Field
+	}
+	return Default_Defaults_Int32Field
+}
+
+func (x *Defaults) GetInt64Field() int64 {
+	if x != nil && x.Int64Field != nil {
+		return *x.Int64Field
+	}
+	return Default_Defaults_Int64Field
+}
+
+func (x *Defaults) GetStringField() string {
+	if x != nil && x.StringField != nil {
+		return *x.StringField
+	}
+	return Default_Defaults_StringField
+}
+
+func (x *Defaults) GetUint32Field() uint32 {
+	if x != nil && x.Uint32Field != nil {
+		return *x.Uint32Field
+	}
+	return Default_Defaults_Uint32Field
+}
+
+func (x *Defaults) GetColorField() Color {
+	if x != nil && x.ColorField != nil {
+		return *x.ColorField
+	}
+	return Default_Defaults_ColorField
+}
+
+type Required struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id    *int32    `protobuf:"varint,1,req,name=id" json:"id,omitempty"`
+	Name  *string   `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	Child *Required `protobuf:"bytes,3,opt,name=child" json:"child,omitempty"`
+}
+
+func (x *Required) Reset() {
+	*x = Required{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tests_api_proto2_v1_simple_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Required) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Required) ProtoMessage() {}
+
+func (x *Required) ProtoReflect() protoreflect.Message {
+	mi := &file_tests_api_proto2_v1_simple_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Required.ProtoReflect.Descriptor instead.
+func (*Required) Descriptor() ([]byte, []int) {
+	return file_tests_api_proto2_v1_simple_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Required) GetId() int32 {
+	if x != nil && x.Id != nil {
+		return *x.Id
+	}
+	return 0
+}
+
+func (x *Required) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *Required) GetChild() *Required {
+	if x != nil {
+		return x.Child
+	}
+	return nil
+}
+
+type Grouped struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Data *Grouped_Data   `protobuf:"group,1,opt,name=Data,json=data" json:"data,omitempty"`
+	Item []*Grouped_Item `protobuf:"group,4,rep,name=Item,json=item" json:"item,omitempty"`
+}
+
+func (x *Grouped) Reset() {
+	*x = Grouped{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tests_api_proto2_v1_simple_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Grouped) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Grouped) ProtoMessage() {}
+
+func (x *Grouped) ProtoReflect() protoreflect.Message {
+	mi := &file_tests_api_proto2_v1_simple_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Grouped.ProtoReflect.Descriptor instead.
+func (*Grouped) Descriptor() ([]byte, []int) {
+	return file_tests_api_proto2_v1_simple_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Grouped) GetData() *Grouped_Data {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *Grouped) GetItem() []*Grouped_Item {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+// Ungrouped is Grouped with its groups turned into nested messages.
+type Ungrouped struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Data *Ungrouped_Data   `protobuf:"bytes,1,opt,name=data" json:"data,omitempty"`
+	Item []*Ungrouped_Item `protobuf:"bytes,4,rep,name=item" json:"item,omitempty"`
+}
+
+func (x *Ungrouped) Reset() {
+	*x = Ungrouped{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tests_api_proto2_v1_simple_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Ungrouped) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ungrouped) ProtoMessage() {}
+
+func (x *Ungrouped) ProtoReflect() protoreflect.Message {
+	mi := &file_tests_api_proto2_v1_simple_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ungrouped.ProtoReflect.Descriptor instead.
+func (*Ungrouped) Descriptor() ([]byte, []int) {
+	return file_tests_api_proto2_v1_simple_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Ungrouped) GetData() *Ungrouped_Data {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *Ungrouped) GetItem() []*Ungrouped_Item {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+type Grouped_Data struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value *int32  `protobuf:"varint,2,opt,name=value" json:"value,omitempty"`
+	Name  *string `protobuf:"bytes,3,opt,name=name" json:"name,omitempty"`
+}
+
+func (x *Grouped_Data) Reset() {
+	*x = Grouped_Data{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tests_api_proto2_v1_simple_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Grouped_Data) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Grouped_Data) ProtoMessage() {}
+
+func (x *Grouped_Data) ProtoReflect() protoreflect.Message {
+	mi := &file_tests_api_proto2_v1_simple_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Grouped_Data.ProtoReflect.Descriptor instead.
+func (*Grouped_Data) Descriptor() ([]byte, []int) {
+	return file_tests_api_proto2_v1_simple_proto_rawDescGZIP(), []int{4, 0}
+}
+
+func (x *Grouped_Data) GetValue() int32 {
+	if x != nil && x.Value != nil {
+		return *x.Value
+	}
+	return 0
+}
+
+func (x *Grouped_Data) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+type Grouped_Item struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value *int32 `protobuf:"varint,5,opt,name=value" json:"value,omitempty"`
+}
+
+func (x *Grouped_Item) Reset() {
+	*x = Grouped_Item{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tests_api_proto2_v1_simple_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Grouped_Item) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Grouped_Item) ProtoMessage() {}
+
+func (x *Grouped_Item) ProtoReflect() protoreflect.Message {
+	mi := &file_tests_api_proto2_v1_simple_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Grouped_Item.ProtoReflect.Descriptor instead.
+func (*Grouped_Item) Descriptor() ([]byte, []int) {
+	return file_tests_api_proto2_v1_simple_proto_rawDescGZIP(), []int{4, 1}
+}
+
+func (x *Grouped_Item) GetValue() int32 {
+	if x != nil && x.Value != nil {
+		return *x.Value
+	}
+	return 0
+}
+
+type Ungrouped_Data struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value *int32  `protobuf:"varint,2,opt,name=value" json:"value,omitempty"`
+	Name  *string `protobuf:"bytes,3,opt,name=name" json:"name,omitempty"`
+}
+
+func (x *Ungrouped_Data) Reset() {
+	*x = Ungrouped_Data{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tests_api_proto2_v1_simple_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Ungrouped_Data) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ungrouped_Data) ProtoMessage() {}
+
+func (x *Ungrouped_Data) ProtoReflect() protoreflect.Message {
+	mi := &file_tests_api_proto2_v1_simple_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ungrouped_Data.ProtoReflect.Descriptor instead.
+func (*Ungrouped_Data) Descriptor() ([]byte, []int) {
+	return file_tests_api_proto2_v1_simple_proto_rawDescGZIP(), []int{5, 0}
+}
+
+func (x *Ungrouped_Data) GetValue() int32 {
+	if x != nil && x.Value != nil {
+		return *x.Value
+	}
+	return 0
+}
+
+func (x *Ungrouped_Data) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+type Ungrouped_Item struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value *int32 `protobuf:"varint,5,opt,name=value" json:"value,omitempty"`
+}
+
+func (x *Ungrouped_Item) Reset() {
+	*x = Ungrouped_Item{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tests_api_proto2_v1_simple_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Ungrouped_Item) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ungrouped_Item) ProtoMessage() {}
+
+func (x *Ungrouped_Item) ProtoReflect() protoreflect.Message {
+	mi := &file_tests_api_proto2_v1_simple_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ungrouped_Item.ProtoReflect.Descriptor instead.
+func (*Ungrouped_Item) Descriptor() ([]byte, []int) {
+	return file_tests_api_proto2_v1_simple_proto_rawDescGZIP(), []int{5, 1}
+}
+
+func (x *Ungrouped_Item) GetValue() int32 {
+	if x != nil && x.Value != nil {
+		return *x.Value
+	}
+	return 0
+}
+
+var File_tests_api_proto2_v1_simple_proto protoreflect.FileDescriptor
+
+var file_tests_api_proto2_v1_simple_proto_rawDesc = []byte{
+	0x0a, 0x20, 0x74, 0x65, 0x73, 0x74, 0x73, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x32, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x13, 0x74, 0x65, 0x73, 0x74, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x32, 0x2e, 0x76, 0x31, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x22, 0x92, 0x05, 0x0a, 0x06, 0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x62,
+	0x6f, 0x6f, 0x6c, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x09, 0x62, 0x6f, 0x6f, 0x6c, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x79,
+	0x74, 0x65, 0x73, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x0a, 0x62, 0x79, 0x74, 0x65, 0x73, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x64,
+	0x6f, 0x75, 0x62, 0x6c, 0x65, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x0b, 0x64, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x23,
+	0x0a, 0x0d, 0x66, 0x69, 0x78, 0x65, 0x64, 0x33, 0x32, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x07, 0x52, 0x0c, 0x66, 0x69, 0x78, 0x65, 0x64, 0x33, 0x32, 0x46, 0x69,
+	0x65, 0x6c, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x66, 0x69, 0x78, 0x65, 0x64, 0x36, 0x34, 0x5f, 0x66,
+	0x69, 0x65, 0x6c, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x06, 0x52, 0x0c, 0x66, 0x69, 0x78, 0x65,
+	0x64, 0x36, 0x34, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x6c, 0x6f, 0x61,
+	0x74, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0a, 0x66,
+	0x6c, 0x6f, 0x61, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x6e, 0x74,
+	0x33, 0x32, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a,
+	0x69, 0x6e, 0x74, 0x33, 0x32, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x6e,
+	0x74, 0x36, 0x34, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0a, 0x69, 0x6e, 0x74, 0x36, 0x34, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x73,
+	0x66, 0x69, 0x78, 0x65, 0x64, 0x33, 0x32, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x11, 0x20,
+	0x01, 0x28, 0x0f, 0x52, 0x0d, 0x73, 0x66, 0x69, 0x78, 0x65, 0x64, 0x33, 0x32, 0x46, 0x69, 0x65,
+	0x6c, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x66, 0x69, 0x78, 0x65, 0x64, 0x36, 0x34, 0x5f, 0x66,
+	0x69, 0x65, 0x6c, 0x64, 0x18, 0x13, 0x20, 0x01, 0x28, 0x10, 0x52, 0x0d, 0x73, 0x66, 0x69, 0x78,
+	0x65, 0x64, 0x36, 0x34, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x69, 0x6e,
+	0x74, 0x33, 0x32, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x15, 0x20, 0x01, 0x28, 0x11, 0x52,
+	0x0b, 0x73, 0x69, 0x6e, 0x74, 0x33, 0x32, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x21, 0x0a, 0x0c,
+	0x73, 0x69, 0x6e, 0x74, 0x36, 0x34, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x17, 0x20, 0x01,
+	0x28, 0x12, 0x52, 0x0b, 0x73, 0x69, 0x6e, 0x74, 0x36, 0x34, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12,
+	0x21, 0x0a, 0x0c, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18,
+	0x19, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x46, 0x69, 0x65,
+	0x6c, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x75, 0x69, 0x6e, 0x74, 0x33, 0x32, 0x5f, 0x66, 0x69, 0x65,
+	0x6c, 0x64, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x75, 0x69, 0x6e, 0x74, 0x33, 0x32,
+	0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x75, 0x69, 0x6e, 0x74, 0x36, 0x34, 0x5f,
+	0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x1d, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x75, 0x69, 0x6e,
+	0x74, 0x36, 0x34, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x3e, 0x0a, 0x0c, 0x73, 0x69, 0x6d, 0x70,
+	0x6c, 0x65, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b,
+	0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x32, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x52, 0x0b, 0x73, 0x69, 0x6d,
+	0x70, 0x6c, 0x65, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x3b, 0x0a, 0x0b, 0x63, 0x6f, 0x6c, 0x6f,
+	0x72, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x25, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e,
+	0x74, 0x65, 0x73, 0x74, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x0a, 0x63, 0x6f, 0x6c, 0x6f, 0x72,
+	0x46, 0x69, 0x65, 0x6c, 0x64, 0x22, 0xe6, 0x02, 0x0a, 0x08, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c,
+	0x74, 0x73, 0x12, 0x23, 0x0a, 0x0a, 0x62, 0x6f, 0x6f, 0x6c, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x3a, 0x04, 0x74, 0x72, 0x75, 0x65, 0x52, 0x09, 0x62, 0x6f,
+	0x6f, 0x6c, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x26, 0x0a, 0x0b, 0x62, 0x79, 0x74, 0x65, 0x73,
+	0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x3a, 0x05, 0x62, 0x79,
+	0x74, 0x65, 0x73, 0x52, 0x0a, 0x62, 0x79, 0x74, 0x65, 0x73, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12,
+	0x26, 0x0a, 0x0c, 0x64, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x01, 0x3a, 0x03, 0x31, 0x2e, 0x35, 0x52, 0x0b, 0x64, 0x6f, 0x75, 0x62,
+	0x6c, 0x65, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x23, 0x0a, 0x0b, 0x69, 0x6e, 0x74, 0x33, 0x32,
+	0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x02, 0x2d, 0x37,
+	0x52, 0x0a, 0x69, 0x6e, 0x74, 0x33, 0x32, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x23, 0x0a, 0x0b,
+	0x69, 0x6e, 0x74, 0x36, 0x34, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x0f, 0x20, 0x01, 0x28,
+	0x03, 0x3a, 0x02, 0x36, 0x34, 0x52, 0x0a, 0x69, 0x6e, 0x74, 0x36, 0x34, 0x46, 0x69, 0x65, 0x6c,
+	0x64, 0x12, 0x2a, 0x0a, 0x0c, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x66, 0x69, 0x65, 0x6c,
+	0x64, 0x18, 0x19, 0x20, 0x01, 0x28, 0x09, 0x3a, 0x07, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74,
+	0x52, 0x0b, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x25, 0x0a,
+	0x0c, 0x75, 0x69, 0x6e, 0x74, 0x33, 0x32, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x1b, 0x20,
+	0x01, 0x28, 0x0d, 0x3a, 0x02, 0x33, 0x32, 0x52, 0x0b, 0x75, 0x69, 0x6e, 0x74, 0x33, 0x32, 0x46,
+	0x69, 0x65, 0x6c, 0x64, 0x12, 0x48, 0x0a, 0x0b, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x5f, 0x66, 0x69,
+	0x65, 0x6c, 0x64, 0x18, 0x25, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x74, 0x65, 0x73, 0x74,
+	0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0x2e, 0x76, 0x31, 0x2e,
+	0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x3a, 0x0b, 0x43, 0x4f, 0x4c, 0x4f, 0x52, 0x5f, 0x47, 0x52, 0x45,
+	0x45, 0x4e, 0x52, 0x0a, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x22, 0x63,
+	0x0a, 0x08, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x02, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x33,
+	0x0a, 0x05, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e,
+	0x74, 0x65, 0x73, 0x74, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32,
+	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x52, 0x05, 0x63, 0x68,
+	0x69, 0x6c, 0x64, 0x22, 0xc7, 0x01, 0x0a, 0x07, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x65, 0x64, 0x12,
+	0x35, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0a, 0x32, 0x21, 0x2e,
+	0x74, 0x65, 0x73, 0x74, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32,
+	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x65, 0x64, 0x2e, 0x44, 0x61, 0x74, 0x61,
+	0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x35, 0x0a, 0x04, 0x69, 0x74, 0x65, 0x6d, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x0a, 0x32, 0x21, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x65, 0x64, 0x2e, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x04, 0x69, 0x74, 0x65, 0x6d, 0x1a, 0x30, 0x0a,
+	0x04, 0x44, 0x61, 0x74, 0x61, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x1a,
+	0x1c, 0x0a, 0x04, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xcd, 0x01,
+	0x0a, 0x09, 0x55, 0x6e, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x65, 0x64, 0x12, 0x37, 0x0a, 0x04, 0x64,
+	0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x74, 0x65, 0x73, 0x74,
+	0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0x2e, 0x76, 0x31, 0x2e,
+	0x55, 0x6e, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x65, 0x64, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x04,
+	0x64, 0x61, 0x74, 0x61, 0x12, 0x37, 0x0a, 0x04, 0x69, 0x74, 0x65, 0x6d, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x23, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x32, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x65, 0x64, 0x2e, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x04, 0x69, 0x74, 0x65, 0x6d, 0x1a, 0x30, 0x0a,
+	0x04, 0x44, 0x61, 0x74, 0x61, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x1a,
+	0x1c, 0x0a, 0x04, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x2a, 0x3a, 0x0a,
+	0x05, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x11, 0x0a, 0x0d, 0x43, 0x4f, 0x4c, 0x4f, 0x52, 0x5f,
+	0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x43, 0x4f, 0x4c,
+	0x4f, 0x52, 0x5f, 0x52, 0x45, 0x44, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x43, 0x4f, 0x4c, 0x4f,
+	0x52, 0x5f, 0x47, 0x52, 0x45, 0x45, 0x4e, 0x10, 0x02, 0x42, 0x39, 0x5a, 0x37, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x73, 0x65, 0x72, 0x74, 0x6f, 0x2d, 0x64,
+	0x65, 0x76, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x68, 0x61, 0x73, 0x68, 0x2f, 0x74, 0x65, 0x73,
+	0x74, 0x73, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0x2f, 0x76, 0x31,
+	0x3b, 0x61, 0x70, 0x69,
+}
+
+var (
+	file_tests_api_proto2_v1_simple_proto_rawDescOnce sync.Once
+	file_tests_api_proto2_v1_simple_proto_rawDescData = file_tests_api_proto2_v1_simple_proto_rawDesc
+)
+
+func file_tests_api_proto2_v1_simple_proto_rawDescGZIP() []byte {
+	file_tests_api_proto2_v1_simple_proto_rawDescOnce.Do(func() {
+		file_tests_api_proto2_v1_simple_proto_rawDescData = protoimpl.X.CompressGZIP(file_tests_api_proto2_v1_simple_proto_rawDescData)
+	})
+	return file_tests_api_proto2_v1_simple_proto_rawDescData
+}
+
+var file_tests_api_proto2_v1_simple_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_tests_api_proto2_v1_simple_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_tests_api_proto2_v1_simple_proto_goTypes = []interface{}{
+	(Color)(0),             // 0: tests.api.proto2.v1.Color
+	(*Empty)(nil),          // 1: tests.api.proto2.v1.Empty
+	(*Simple)(nil),         // 2: tests.api.proto2.v1.Simple
+	(*Defaults)(nil),       // 3: tests.api.proto2.v1.Defaults
+	(*Required)(nil),       // 4: tests.api.proto2.v1.Required
+	(*Grouped)(nil),        // 5: tests.api.proto2.v1.Grouped
+	(*Ungrouped)(nil),      // 6: tests.api.proto2.v1.Ungrouped
+	(*Grouped_Data)(nil),   // 7: tests.api.proto2.v1.Grouped.Data
+	(*Grouped_Item)(nil),   // 8: tests.api.proto2.v1.Grouped.Item
+	(*Ungrouped_Data)(nil), // 9: tests.api.proto2.v1.Ungrouped.Data
+	(*Ungrouped_Item)(nil), // 10: tests.api.proto2.v1.Ungrouped.Item
+}
+var file_tests_api_proto2_v1_simple_proto_depIdxs = []int32{
+	2,  // 0: tests.api.proto2.v1.Simple.simple_field:type_name -> tests.api.proto2.v1.Simple
+	0,  // 1: tests.api.proto2.v1.Simple.color_field:type_name -> tests.api.proto2.v1.Color
+	0,  // 2: tests.api.proto2.v1.Defaults.color_field:type_name -> tests.api.proto2.v1.Color
+	4,  // 3: tests.api.proto2.v1.Required.child:type_name -> tests.api.proto2.v1.Required
+	7,  // 4: tests.api.proto2.v1.Grouped.data:type_name -> tests.api.proto2.v1.Grouped.Data
+	8,  // 5: tests.api.proto2.v1.Grouped.item:type_name -> tests.api.proto2.v1.Grouped.Item
+	9,  // 6: tests.api.proto2.v1.Ungrouped.data:type_name -> tests.api.proto2.v1.Ungrouped.Data
+	10, // 7: tests.api.proto2.v1.Ungrouped.item:type_name -> tests.api.proto2.v1.Ungrouped.Item
+	8,  // [8:8] is the sub-list for method output_type
+	8,  // [8:8] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_tests_api_proto2_v1_simple_proto_init() }
+func file_tests_api_proto2_v1_simple_proto_init() {
+	if File_tests_api_proto2_v1_simple_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_tests_api_proto2_v1_simple_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Empty); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tests_api_proto2_v1_simple_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Simple); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tests_api_proto2_v1_simple_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Defaults); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tests_api_proto2_v1_simple_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Required); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tests_api_proto2_v1_simple_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Grouped); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tests_api_proto2_v1_simple_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Ungrouped); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tests_api_proto2_v1_simple_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Grouped_Data); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tests_api_proto2_v1_simple_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Grouped_Item); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tests_api_proto2_v1_simple_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Ungrouped_Data); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tests_api_proto2_v1_simple_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Ungrouped_Item); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_tests_api_proto2_v1_simple_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_tests_api_proto2_v1_simple_proto_goTypes,
+		DependencyIndexes: file_tests_api_proto2_v1_simple_proto_depIdxs,
+		EnumInfos:         file_tests_api_proto2_v1_simple_proto_enumTypes,
+		MessageInfos:      file_tests_api_proto2_v1_simple_proto_msgTypes,
+	}.Build()
+	File_tests_api_proto2_v1_simple_proto = out.File
+	file_tests_api_proto2_v1_simple_proto_rawDesc = nil
+	file_tests_api_proto2_v1_simple_proto_goTypes = nil
+	file_tests_api_proto2_v1_simple_proto_depIdxs = nil
+}
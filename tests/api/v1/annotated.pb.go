@@ -0,0 +1,418 @@
+// This is used for tests that ensure that the hashing options declared in
+// protohash/options.proto are honored.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        (unknown)
+// source: tests/api/v1/annotated.proto
+
+package api
+
+import (
+	_ "github.com/aserto-dev/go-protohash/protohash"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Annotated struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id         string            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Etag       string            `protobuf:"bytes,2,opt,name=etag,proto3" json:"etag,omitempty"`
+	Tags       []string          `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	Labels     []string          `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty"`
+	Name       string            `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
+	Attributes map[string]string `protobuf:"bytes,6,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Aliases    []string          `protobuf:"bytes,7,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	Child      *Annotated        `protobuf:"bytes,8,opt,name=child,proto3" json:"child,omitempty"`
+}
+
+func (x *Annotated) Reset() {
+	*x = Annotated{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tests_api_v1_annotated_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Annotated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Annotated) ProtoMessage() {}
+
+func (x *Annotated) ProtoReflect() protoreflect.Message {
+	mi := &file_tests_api_v1_annotated_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Annotated.ProtoReflect.Descriptor instead.
+func (*Annotated) Descriptor() ([]byte, []int) {
+	return file_tests_api_v1_annotated_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Annotated) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Annotated) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+func (x *Annotated) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Annotated) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Annotated) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Annotated) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *Annotated) GetAliases() []string {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+func (x *Annotated) GetChild() *Annotated {
+	if x != nil {
+		return x.Child
+	}
+	return nil
+}
+
+type TypedName struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *TypedName) Reset() {
+	*x = TypedName{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tests_api_v1_annotated_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TypedName) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TypedName) ProtoMessage() {}
+
+func (x *TypedName) ProtoReflect() protoreflect.Message {
+	mi := &file_tests_api_v1_annotated_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TypedName.ProtoReflect.Descriptor instead.
+func (*TypedName) Descriptor() ([]byte, []int) {
+	return file_tests_api_v1_annotated_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TypedName) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type TypedLabel struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *TypedLabel) Reset() {
+	*x = TypedLabel{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tests_api_v1_annotated_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TypedLabel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TypedLabel) ProtoMessage() {}
+
+func (x *TypedLabel) ProtoReflect() protoreflect.Message {
+	mi := &file_tests_api_v1_annotated_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TypedLabel.ProtoReflect.Descriptor instead.
+func (*TypedLabel) Descriptor() ([]byte, []int) {
+	return file_tests_api_v1_annotated_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TypedLabel) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type UntypedName struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *UntypedName) Reset() {
+	*x = UntypedName{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tests_api_v1_annotated_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UntypedName) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UntypedName) ProtoMessage() {}
+
+func (x *UntypedName) ProtoReflect() protoreflect.Message {
+	mi := &file_tests_api_v1_annotated_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UntypedName.ProtoReflect.Descriptor instead.
+func (*UntypedName) Descriptor() ([]byte, []int) {
+	return file_tests_api_v1_annotated_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UntypedName) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+var File_tests_api_v1_annotated_proto protoreflect.FileDescriptor
+
+var file_tests_api_v1_annotated_proto_rawDesc = []byte{
+	0x0a, 0x1c, 0x74, 0x65, 0x73, 0x74, 0x73, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x61,
+	0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c,
+	0x74, 0x65, 0x73, 0x74, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x1a, 0x17, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x68, 0x61, 0x73, 0x68, 0x2f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xf0, 0x02, 0x0a, 0x09, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61,
+	0x74, 0x65, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x04, 0x65, 0x74, 0x61, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x42, 0x06, 0x8a, 0xac, 0x1b, 0x02, 0x08, 0x01, 0x52, 0x04, 0x65, 0x74, 0x61, 0x67, 0x12,
+	0x1a, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x42, 0x06, 0x8a,
+	0xac, 0x1b, 0x02, 0x10, 0x01, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x12, 0x1e, 0x0a, 0x06, 0x6c,
+	0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x42, 0x06, 0x8a, 0xac, 0x1b,
+	0x02, 0x10, 0x02, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x1a, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x42, 0x06, 0x8a, 0xac, 0x1b, 0x02, 0x18,
+	0x01, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x4f, 0x0a, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69,
+	0x62, 0x75, 0x74, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x74, 0x65,
+	0x73, 0x74, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6e, 0x6e, 0x6f, 0x74,
+	0x61, 0x74, 0x65, 0x64, 0x2e, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x42, 0x06, 0x8a, 0xac, 0x1b, 0x02, 0x18, 0x01, 0x52, 0x0a, 0x61, 0x74,
+	0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x07, 0x61, 0x6c, 0x69, 0x61,
+	0x73, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x42, 0x06, 0x8a, 0xac, 0x1b, 0x02, 0x18,
+	0x01, 0x52, 0x07, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x65, 0x73, 0x12, 0x2d, 0x0a, 0x05, 0x63, 0x68,
+	0x69, 0x6c, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x74, 0x65, 0x73, 0x74,
+	0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74,
+	0x65, 0x64, 0x52, 0x05, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x1a, 0x3d, 0x0a, 0x0f, 0x41, 0x74, 0x74,
+	0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x29, 0x0a, 0x09, 0x54, 0x79, 0x70, 0x65,
+	0x64, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x06, 0x8a, 0xac, 0x1b,
+	0x02, 0x08, 0x01, 0x22, 0x2a, 0x0a, 0x0a, 0x54, 0x79, 0x70, 0x65, 0x64, 0x4c, 0x61, 0x62, 0x65,
+	0x6c, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x06, 0x8a, 0xac, 0x1b, 0x02, 0x08, 0x01, 0x22,
+	0x23, 0x0a, 0x0b, 0x55, 0x6e, 0x74, 0x79, 0x70, 0x65, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x42, 0x32, 0x5a, 0x30, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x61, 0x73, 0x65, 0x72, 0x74, 0x6f, 0x2d, 0x64, 0x65, 0x76, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x68, 0x61, 0x73, 0x68, 0x2f, 0x74, 0x65, 0x73, 0x74, 0x73, 0x2f, 0x61, 0x70,
+	0x69, 0x2f, 0x76, 0x31, 0x3b, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_tests_api_v1_annotated_proto_rawDescOnce sync.Once
+	file_tests_api_v1_annotated_proto_rawDescData = file_tests_api_v1_annotated_proto_rawDesc
+)
+
+func file_tests_api_v1_annotated_proto_rawDescGZIP() []byte {
+	file_tests_api_v1_annotated_proto_rawDescOnce.Do(func() {
+		file_tests_api_v1_annotated_proto_rawDescData = protoimpl.X.CompressGZIP(file_tests_api_v1_annotated_proto_rawDescData)
+	})
+	return file_tests_api_v1_annotated_proto_rawDescData
+}
+
+var file_tests_api_v1_annotated_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_tests_api_v1_annotated_proto_goTypes = []interface{}{
+	(*Annotated)(nil),   // 0: tests.api.v1.Annotated
+	(*TypedName)(nil),   // 1: tests.api.v1.TypedName
+	(*TypedLabel)(nil),  // 2: tests.api.v1.TypedLabel
+	(*UntypedName)(nil), // 3: tests.api.v1.UntypedName
+	nil,                 // 4: tests.api.v1.Annotated.AttributesEntry
+}
+var file_tests_api_v1_annotated_proto_depIdxs = []int32{
+	4, // 0: tests.api.v1.Annotated.attributes:type_name -> tests.api.v1.Annotated.AttributesEntry
+	0, // 1: tests.api.v1.Annotated.child:type_name -> tests.api.v1.Annotated
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_tests_api_v1_annotated_proto_init() }
+func file_tests_api_v1_annotated_proto_init() {
+	if File_tests_api_v1_annotated_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_tests_api_v1_annotated_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Annotated); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tests_api_v1_annotated_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TypedName); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tests_api_v1_annotated_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TypedLabel); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tests_api_v1_annotated_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UntypedName); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_tests_api_v1_annotated_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_tests_api_v1_annotated_proto_goTypes,
+		DependencyIndexes: file_tests_api_v1_annotated_proto_depIdxs,
+		MessageInfos:      file_tests_api_v1_annotated_proto_msgTypes,
+	}.Build()
+	File_tests_api_v1_annotated_proto = out.File
+	file_tests_api_v1_annotated_proto_rawDesc = nil
+	file_tests_api_v1_annotated_proto_goTypes = nil
+	file_tests_api_v1_annotated_proto_depIdxs = nil
+}
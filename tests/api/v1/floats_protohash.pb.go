@@ -0,0 +1,87 @@
+// Code generated by protoc-gen-go-protohash. DO NOT EDIT.
+// source: tests/api/v1/floats.proto
+
+package api
+
+import (
+	go_protohash "github.com/aserto-dev/go-protohash"
+	math "math"
+)
+
+// ProtoHash returns the hash of x, as protohash.ProtoHasher.HashMessage does.
+func (x *DoubleMessage) ProtoHash(s *go_protohash.State) (uint64, error) {
+	if x == nil {
+		return 0, nil
+	}
+
+	var (
+		h, hv uint64
+		err   error
+	)
+
+	if math.Float64bits(x.Value) != 0 {
+		if hv, err = s.HashFloat(float64(x.Value)); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 1, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(x.Values) > 0 {
+		var hl uint64
+		for i := len(x.Values) - 1; i >= 0; i-- {
+			if hv, err = s.HashFloat(float64(x.Values[i])); err != nil {
+				return 0, err
+			}
+			if hl, err = s.CombineOrdered(hl, hv); err != nil {
+				return 0, err
+			}
+		}
+		hv = hl
+		if h, err = s.CombineField(h, 2, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	return h, nil
+}
+
+// ProtoHash returns the hash of x, as protohash.ProtoHasher.HashMessage does.
+func (x *FloatMessage) ProtoHash(s *go_protohash.State) (uint64, error) {
+	if x == nil {
+		return 0, nil
+	}
+
+	var (
+		h, hv uint64
+		err   error
+	)
+
+	if math.Float32bits(x.Value) != 0 {
+		if hv, err = s.HashFloat(float64(x.Value)); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 1, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(x.Values) > 0 {
+		var hl uint64
+		for i := len(x.Values) - 1; i >= 0; i-- {
+			if hv, err = s.HashFloat(float64(x.Values[i])); err != nil {
+				return 0, err
+			}
+			if hl, err = s.CombineOrdered(hl, hv); err != nil {
+				return 0, err
+			}
+		}
+		hv = hl
+		if h, err = s.CombineField(h, 2, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	return h, nil
+}
@@ -0,0 +1,320 @@
+// Code generated by protoc-gen-go-protohash. DO NOT EDIT.
+// source: tests/api/v1/people.proto
+
+package api
+
+import (
+	go_protohash "github.com/aserto-dev/go-protohash"
+)
+
+// ProtoHash returns the hash of x, as protohash.ProtoHasher.HashMessage does.
+func (x *PersonV1) ProtoHash(s *go_protohash.State) (uint64, error) {
+	if x == nil {
+		return 0, nil
+	}
+
+	var (
+		h, hv uint64
+		err   error
+	)
+
+	if x.Id != 0 {
+		if hv, err = s.HashInt(int64(x.Id)); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 1, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if x.Name != "" {
+		if hv, err = s.HashString(x.Name); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 2, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	return h, nil
+}
+
+// ProtoHash returns the hash of x, as protohash.ProtoHasher.HashMessage does.
+func (x *PersonV2) ProtoHash(s *go_protohash.State) (uint64, error) {
+	if x == nil {
+		return 0, nil
+	}
+
+	var (
+		h, hv uint64
+		err   error
+	)
+
+	if x.Id != 0 {
+		if hv, err = s.HashInt(int64(x.Id)); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 1, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if x.Name != "" {
+		if hv, err = s.HashString(x.Name); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 2, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if x.Age != 0 {
+		if hv, err = s.HashUint(uint64(x.Age)); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 3, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if x.Profession != "" {
+		if hv, err = s.HashString(x.Profession); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 4, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(x.Children) > 0 {
+		var hl uint64
+		for i := len(x.Children) - 1; i >= 0; i-- {
+			if hv, err = s.HashMessage(x.Children[i]); err != nil {
+				return 0, err
+			}
+			if hl, err = s.CombineOrdered(hl, hv); err != nil {
+				return 0, err
+			}
+		}
+		hv = hl
+		if h, err = s.CombineField(h, 5, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	return h, nil
+}
+
+// ProtoHash returns the hash of x, as protohash.ProtoHasher.HashMessage does.
+func (x *PersonV3) ProtoHash(s *go_protohash.State) (uint64, error) {
+	if x == nil {
+		return 0, nil
+	}
+
+	var (
+		h, hv uint64
+		err   error
+	)
+
+	if x.Id != 0 {
+		if hv, err = s.HashInt(int64(x.Id)); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 1, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if o, ok := x.Name.(*PersonV3_FullName); ok {
+		if hv, err = s.HashString(o.FullName); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 2, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if x.Age != 0 {
+		if hv, err = s.HashUint(uint64(x.Age)); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 3, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if x.Profession != "" {
+		if hv, err = s.HashString(x.Profession); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 4, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(x.Children) > 0 {
+		var hl uint64
+		for i := len(x.Children) - 1; i >= 0; i-- {
+			if hv, err = s.HashMessage(x.Children[i]); err != nil {
+				return 0, err
+			}
+			if hl, err = s.CombineOrdered(hl, hv); err != nil {
+				return 0, err
+			}
+		}
+		hv = hl
+		if h, err = s.CombineField(h, 5, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if o, ok := x.Name.(*PersonV3_StructuredName); ok {
+		if hv, err = s.HashMessage(o.StructuredName); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 6, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	return h, nil
+}
+
+// ProtoHash returns the hash of x, as protohash.ProtoHasher.HashMessage does.
+func (x *PersonV3_NameV3) ProtoHash(s *go_protohash.State) (uint64, error) {
+	if x == nil {
+		return 0, nil
+	}
+
+	var (
+		h, hv uint64
+		err   error
+	)
+
+	if x.First != "" {
+		if hv, err = s.HashString(x.First); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 1, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if x.Last != "" {
+		if hv, err = s.HashString(x.Last); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 2, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	return h, nil
+}
+
+// ProtoHash returns the hash of x, as protohash.ProtoHasher.HashMessage does.
+func (x *PersonV4) ProtoHash(s *go_protohash.State) (uint64, error) {
+	if x == nil {
+		return 0, nil
+	}
+
+	var (
+		h, hv uint64
+		err   error
+	)
+
+	if x.Id != 0 {
+		if hv, err = s.HashInt(int64(x.Id)); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 1, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if x.DeprecatedFullName != "" {
+		if hv, err = s.HashString(x.DeprecatedFullName); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 2, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if x.Age != 0 {
+		if hv, err = s.HashUint(uint64(x.Age)); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 3, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if x.Profession != "" {
+		if hv, err = s.HashString(x.Profession); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 4, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(x.Children) > 0 {
+		var hl uint64
+		for i := len(x.Children) - 1; i >= 0; i-- {
+			if hv, err = s.HashMessage(x.Children[i]); err != nil {
+				return 0, err
+			}
+			if hl, err = s.CombineOrdered(hl, hv); err != nil {
+				return 0, err
+			}
+		}
+		hv = hl
+		if h, err = s.CombineField(h, 5, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if x.StructuredName != nil {
+		if hv, err = s.HashMessage(x.StructuredName); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 6, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	return h, nil
+}
+
+// ProtoHash returns the hash of x, as protohash.ProtoHasher.HashMessage does.
+func (x *PersonV4_NameV4) ProtoHash(s *go_protohash.State) (uint64, error) {
+	if x == nil {
+		return 0, nil
+	}
+
+	var (
+		h, hv uint64
+		err   error
+	)
+
+	if x.First != "" {
+		if hv, err = s.HashString(x.First); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 1, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if x.Last != "" {
+		if hv, err = s.HashString(x.Last); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 2, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	return h, nil
+}
@@ -0,0 +1,668 @@
+// Code generated by protoc-gen-go-protohash. DO NOT EDIT.
+// source: tests/api/v1/simple.proto
+
+package api
+
+import (
+	go_protohash "github.com/aserto-dev/go-protohash"
+	math "math"
+)
+
+// ProtoHash returns the hash of x, as protohash.ProtoHasher.HashMessage does.
+func (x *Empty) ProtoHash(s *go_protohash.State) (uint64, error) {
+	return 0, nil
+}
+
+// ProtoHash returns the hash of x, as protohash.ProtoHasher.HashMessage does.
+func (x *Simple) ProtoHash(s *go_protohash.State) (uint64, error) {
+	if x == nil {
+		return 0, nil
+	}
+
+	var (
+		h, hv uint64
+		err   error
+	)
+
+	if x.BoolField {
+		if hv, err = s.HashBool(x.BoolField); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 1, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(x.BytesField) > 0 {
+		if hv, err = s.HashBytes(x.BytesField); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 3, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if math.Float64bits(x.DoubleField) != 0 {
+		if hv, err = s.HashFloat(float64(x.DoubleField)); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 5, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if x.Fixed32Field != 0 {
+		if hv, err = s.HashUint(uint64(x.Fixed32Field)); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 7, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if x.Fixed64Field != 0 {
+		if hv, err = s.HashUint(uint64(x.Fixed64Field)); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 9, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if math.Float32bits(x.FloatField) != 0 {
+		if hv, err = s.HashFloat(float64(x.FloatField)); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 11, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if x.Int32Field != 0 {
+		if hv, err = s.HashInt(int64(x.Int32Field)); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 13, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if x.Int64Field != 0 {
+		if hv, err = s.HashInt(int64(x.Int64Field)); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 15, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if x.Sfixed32Field != 0 {
+		if hv, err = s.HashInt(int64(x.Sfixed32Field)); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 17, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if x.Sfixed64Field != 0 {
+		if hv, err = s.HashInt(int64(x.Sfixed64Field)); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 19, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if x.Sint32Field != 0 {
+		if hv, err = s.HashInt(int64(x.Sint32Field)); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 21, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if x.Sint64Field != 0 {
+		if hv, err = s.HashInt(int64(x.Sint64Field)); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 23, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if x.StringField != "" {
+		if hv, err = s.HashString(x.StringField); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 25, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if x.Uint32Field != 0 {
+		if hv, err = s.HashUint(uint64(x.Uint32Field)); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 27, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if x.Uint64Field != 0 {
+		if hv, err = s.HashUint(uint64(x.Uint64Field)); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 29, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if x.SimpleField != nil {
+		if hv, err = s.HashMessage(x.SimpleField); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 31, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if x.RepetitiveField != nil {
+		if hv, err = s.HashMessage(x.RepetitiveField); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 33, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if x.SingletonField != nil {
+		if hv, err = s.HashMessage(x.SingletonField); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 35, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	return h, nil
+}
+
+// ProtoHash returns the hash of x, as protohash.ProtoHasher.HashMessage does.
+func (x *Repetitive) ProtoHash(s *go_protohash.State) (uint64, error) {
+	if x == nil {
+		return 0, nil
+	}
+
+	var (
+		h, hv uint64
+		err   error
+	)
+
+	if len(x.BoolField) > 0 {
+		var hl uint64
+		for i := len(x.BoolField) - 1; i >= 0; i-- {
+			if hv, err = s.HashBool(x.BoolField[i]); err != nil {
+				return 0, err
+			}
+			if hl, err = s.CombineOrdered(hl, hv); err != nil {
+				return 0, err
+			}
+		}
+		hv = hl
+		if h, err = s.CombineField(h, 1, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(x.BytesField) > 0 {
+		var hl uint64
+		for i := len(x.BytesField) - 1; i >= 0; i-- {
+			if hv, err = s.HashBytes(x.BytesField[i]); err != nil {
+				return 0, err
+			}
+			if hl, err = s.CombineOrdered(hl, hv); err != nil {
+				return 0, err
+			}
+		}
+		hv = hl
+		if h, err = s.CombineField(h, 3, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(x.DoubleField) > 0 {
+		var hl uint64
+		for i := len(x.DoubleField) - 1; i >= 0; i-- {
+			if hv, err = s.HashFloat(float64(x.DoubleField[i])); err != nil {
+				return 0, err
+			}
+			if hl, err = s.CombineOrdered(hl, hv); err != nil {
+				return 0, err
+			}
+		}
+		hv = hl
+		if h, err = s.CombineField(h, 5, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(x.Fixed32Field) > 0 {
+		var hl uint64
+		for i := len(x.Fixed32Field) - 1; i >= 0; i-- {
+			if hv, err = s.HashUint(uint64(x.Fixed32Field[i])); err != nil {
+				return 0, err
+			}
+			if hl, err = s.CombineOrdered(hl, hv); err != nil {
+				return 0, err
+			}
+		}
+		hv = hl
+		if h, err = s.CombineField(h, 7, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(x.Fixed64Field) > 0 {
+		var hl uint64
+		for i := len(x.Fixed64Field) - 1; i >= 0; i-- {
+			if hv, err = s.HashUint(uint64(x.Fixed64Field[i])); err != nil {
+				return 0, err
+			}
+			if hl, err = s.CombineOrdered(hl, hv); err != nil {
+				return 0, err
+			}
+		}
+		hv = hl
+		if h, err = s.CombineField(h, 9, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(x.FloatField) > 0 {
+		var hl uint64
+		for i := len(x.FloatField) - 1; i >= 0; i-- {
+			if hv, err = s.HashFloat(float64(x.FloatField[i])); err != nil {
+				return 0, err
+			}
+			if hl, err = s.CombineOrdered(hl, hv); err != nil {
+				return 0, err
+			}
+		}
+		hv = hl
+		if h, err = s.CombineField(h, 11, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(x.Int32Field) > 0 {
+		var hl uint64
+		for i := len(x.Int32Field) - 1; i >= 0; i-- {
+			if hv, err = s.HashInt(int64(x.Int32Field[i])); err != nil {
+				return 0, err
+			}
+			if hl, err = s.CombineOrdered(hl, hv); err != nil {
+				return 0, err
+			}
+		}
+		hv = hl
+		if h, err = s.CombineField(h, 13, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(x.Int64Field) > 0 {
+		var hl uint64
+		for i := len(x.Int64Field) - 1; i >= 0; i-- {
+			if hv, err = s.HashInt(int64(x.Int64Field[i])); err != nil {
+				return 0, err
+			}
+			if hl, err = s.CombineOrdered(hl, hv); err != nil {
+				return 0, err
+			}
+		}
+		hv = hl
+		if h, err = s.CombineField(h, 15, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(x.Sfixed32Field) > 0 {
+		var hl uint64
+		for i := len(x.Sfixed32Field) - 1; i >= 0; i-- {
+			if hv, err = s.HashInt(int64(x.Sfixed32Field[i])); err != nil {
+				return 0, err
+			}
+			if hl, err = s.CombineOrdered(hl, hv); err != nil {
+				return 0, err
+			}
+		}
+		hv = hl
+		if h, err = s.CombineField(h, 17, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(x.Sfixed64Field) > 0 {
+		var hl uint64
+		for i := len(x.Sfixed64Field) - 1; i >= 0; i-- {
+			if hv, err = s.HashInt(int64(x.Sfixed64Field[i])); err != nil {
+				return 0, err
+			}
+			if hl, err = s.CombineOrdered(hl, hv); err != nil {
+				return 0, err
+			}
+		}
+		hv = hl
+		if h, err = s.CombineField(h, 19, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(x.Sint32Field) > 0 {
+		var hl uint64
+		for i := len(x.Sint32Field) - 1; i >= 0; i-- {
+			if hv, err = s.HashInt(int64(x.Sint32Field[i])); err != nil {
+				return 0, err
+			}
+			if hl, err = s.CombineOrdered(hl, hv); err != nil {
+				return 0, err
+			}
+		}
+		hv = hl
+		if h, err = s.CombineField(h, 21, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(x.Sint64Field) > 0 {
+		var hl uint64
+		for i := len(x.Sint64Field) - 1; i >= 0; i-- {
+			if hv, err = s.HashInt(int64(x.Sint64Field[i])); err != nil {
+				return 0, err
+			}
+			if hl, err = s.CombineOrdered(hl, hv); err != nil {
+				return 0, err
+			}
+		}
+		hv = hl
+		if h, err = s.CombineField(h, 23, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(x.StringField) > 0 {
+		var hl uint64
+		for i := len(x.StringField) - 1; i >= 0; i-- {
+			if hv, err = s.HashString(x.StringField[i]); err != nil {
+				return 0, err
+			}
+			if hl, err = s.CombineOrdered(hl, hv); err != nil {
+				return 0, err
+			}
+		}
+		hv = hl
+		if h, err = s.CombineField(h, 25, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(x.Uint32Field) > 0 {
+		var hl uint64
+		for i := len(x.Uint32Field) - 1; i >= 0; i-- {
+			if hv, err = s.HashUint(uint64(x.Uint32Field[i])); err != nil {
+				return 0, err
+			}
+			if hl, err = s.CombineOrdered(hl, hv); err != nil {
+				return 0, err
+			}
+		}
+		hv = hl
+		if h, err = s.CombineField(h, 27, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(x.Uint64Field) > 0 {
+		var hl uint64
+		for i := len(x.Uint64Field) - 1; i >= 0; i-- {
+			if hv, err = s.HashUint(uint64(x.Uint64Field[i])); err != nil {
+				return 0, err
+			}
+			if hl, err = s.CombineOrdered(hl, hv); err != nil {
+				return 0, err
+			}
+		}
+		hv = hl
+		if h, err = s.CombineField(h, 29, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(x.SimpleField) > 0 {
+		var hl uint64
+		for i := len(x.SimpleField) - 1; i >= 0; i-- {
+			if hv, err = s.HashMessage(x.SimpleField[i]); err != nil {
+				return 0, err
+			}
+			if hl, err = s.CombineOrdered(hl, hv); err != nil {
+				return 0, err
+			}
+		}
+		hv = hl
+		if h, err = s.CombineField(h, 31, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(x.RepetitiveField) > 0 {
+		var hl uint64
+		for i := len(x.RepetitiveField) - 1; i >= 0; i-- {
+			if hv, err = s.HashMessage(x.RepetitiveField[i]); err != nil {
+				return 0, err
+			}
+			if hl, err = s.CombineOrdered(hl, hv); err != nil {
+				return 0, err
+			}
+		}
+		hv = hl
+		if h, err = s.CombineField(h, 33, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(x.SingletonField) > 0 {
+		var hl uint64
+		for i := len(x.SingletonField) - 1; i >= 0; i-- {
+			if hv, err = s.HashMessage(x.SingletonField[i]); err != nil {
+				return 0, err
+			}
+			if hl, err = s.CombineOrdered(hl, hv); err != nil {
+				return 0, err
+			}
+		}
+		hv = hl
+		if h, err = s.CombineField(h, 35, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	return h, nil
+}
+
+// ProtoHash returns the hash of x, as protohash.ProtoHasher.HashMessage does.
+func (x *Singleton) ProtoHash(s *go_protohash.State) (uint64, error) {
+	if x == nil {
+		return 0, nil
+	}
+
+	var (
+		h, hv uint64
+		err   error
+	)
+
+	if o, ok := x.Singleton.(*Singleton_TheBool); ok {
+		if hv, err = s.HashBool(o.TheBool); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 1, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if o, ok := x.Singleton.(*Singleton_TheBytes); ok {
+		if hv, err = s.HashBytes(o.TheBytes); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 3, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if o, ok := x.Singleton.(*Singleton_TheDouble); ok {
+		if hv, err = s.HashFloat(float64(o.TheDouble)); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 5, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if o, ok := x.Singleton.(*Singleton_TheFixed32); ok {
+		if hv, err = s.HashUint(uint64(o.TheFixed32)); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 7, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if o, ok := x.Singleton.(*Singleton_TheFixed64); ok {
+		if hv, err = s.HashUint(uint64(o.TheFixed64)); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 9, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if o, ok := x.Singleton.(*Singleton_TheFloat); ok {
+		if hv, err = s.HashFloat(float64(o.TheFloat)); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 11, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if o, ok := x.Singleton.(*Singleton_TheInt32); ok {
+		if hv, err = s.HashInt(int64(o.TheInt32)); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 13, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if o, ok := x.Singleton.(*Singleton_TheInt64); ok {
+		if hv, err = s.HashInt(int64(o.TheInt64)); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 15, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if o, ok := x.Singleton.(*Singleton_TheSfixed32); ok {
+		if hv, err = s.HashInt(int64(o.TheSfixed32)); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 17, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if o, ok := x.Singleton.(*Singleton_TheSfixed64); ok {
+		if hv, err = s.HashInt(int64(o.TheSfixed64)); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 19, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if o, ok := x.Singleton.(*Singleton_TheSint32); ok {
+		if hv, err = s.HashInt(int64(o.TheSint32)); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 21, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if o, ok := x.Singleton.(*Singleton_TheSint64); ok {
+		if hv, err = s.HashInt(int64(o.TheSint64)); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 23, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if o, ok := x.Singleton.(*Singleton_TheString); ok {
+		if hv, err = s.HashString(o.TheString); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 25, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if o, ok := x.Singleton.(*Singleton_TheUint32); ok {
+		if hv, err = s.HashUint(uint64(o.TheUint32)); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 27, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if o, ok := x.Singleton.(*Singleton_TheUint64); ok {
+		if hv, err = s.HashUint(uint64(o.TheUint64)); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 29, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if o, ok := x.Singleton.(*Singleton_TheSimple); ok {
+		if hv, err = s.HashMessage(o.TheSimple); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 31, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if o, ok := x.Singleton.(*Singleton_TheRepetitive); ok {
+		if hv, err = s.HashMessage(o.TheRepetitive); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 33, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	if o, ok := x.Singleton.(*Singleton_TheSingleton); ok {
+		if hv, err = s.HashMessage(o.TheSingleton); err != nil {
+			return 0, err
+		}
+		if h, err = s.CombineField(h, 35, hv); err != nil {
+			return 0, err
+		}
+	}
+
+	return h, nil
+}
@@ -56,9 +56,11 @@ func TestEmptyFields(t *testing.T, hasher *ph.ProtoHasher) {
 				&api.Simple{Uint32Field: 0},
 				&api.Simple{Uint64Field: 0},
 			},
-			EquivalentJSONString: "{}",
-			EquivalentObject:     map[string]interface{}{},
-			ExpectedHashString:   "0",
+			EquivalentJSONString:     "{}",
+			EquivalentObject:         map[string]interface{}{},
+			ExpectedHashString:       "0",
+			ExpectedObjectHashString: "18ac3e7343f016890c510e93f935261169d9e3f565436429830faf0934f4f8e4",
+			ExpectedHashStringV2:     "0",
 		},
 	}
 
@@ -0,0 +1,142 @@
+package tests
+
+import (
+	"testing"
+
+	ph "github.com/aserto-dev/go-protohash"
+	pb2 "github.com/aserto-dev/go-protohash/tests/api/proto2/v1"
+	tc "github.com/aserto-dev/go-protohash/tests/internal"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// TestExtensions performs tests on hashers created with opts and the extension
+// options.
+func TestExtensions(t *testing.T, opts ...ph.HashOption) {
+	with := func(more ...ph.HashOption) *ph.ProtoHasher {
+		return ph.New(append(append([]ph.HashOption{}, opts...), more...)...)
+	}
+	hasher := with()
+
+	extended := func(m *pb2.Extendable, exts ...interface{}) *pb2.Extendable {
+		for i := 0; i < len(exts); i += 2 {
+			proto.SetExtension(m, exts[i].(protoreflect.ExtensionType), exts[i+1])
+		}
+		return m
+	}
+	hash := func(hasher *ph.ProtoHasher, m proto.Message) uint64 {
+		h, err := hasher.HashMessage(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return h
+	}
+
+	// Extensions hash alike whatever the order in which they were set, and
+	// messages without extensions hash as they do when extensions are excluded.
+	tc.TestCase{
+		Protos: []proto.Message{
+			extended(&pb2.Extendable{Id: proto.Int32(1)}, pb2.E_Count, int32(2), pb2.E_Label, "a", pb2.E_Tags, []string{"b", "c"}),
+			extended(&pb2.Extendable{Id: proto.Int32(1)}, pb2.E_Tags, []string{"b", "c"}, pb2.E_Label, "a", pb2.E_Count, int32(2)),
+		},
+	}.Check(t, hasher)
+	tc.TestCase{
+		Protos: []proto.Message{
+			&pb2.Extendable{Id: proto.Int32(1), Child: &pb2.Extendable{Name: proto.String("a")}},
+		},
+	}.Check(t, hasher)
+	if hash(hasher, &pb2.Extendable{Id: proto.Int32(1)}) != hash(with(ph.WithExtensions(ph.ExtensionsExcluded)), &pb2.Extendable{Id: proto.Int32(1)}) {
+		t.Errorf("A message without extensions does not hash as it does when extensions are excluded")
+	}
+
+	// Extensions do not collide with the fields of the message they extend, nor
+	// with each other, at any depth.
+	for _, mode := range []ph.ExtensionMode{ph.ExtensionsByNumber, ph.ExtensionsByName} {
+		tc.CheckDistinct(t, with(ph.WithExtensions(mode)),
+			&pb2.Extendable{Id: proto.Int32(1)},
+			&pb2.Extendable{Id: proto.Int32(1), Name: proto.String("a")},
+			extended(&pb2.Extendable{Id: proto.Int32(1)}, pb2.E_Label, "a"),
+			extended(&pb2.Extendable{Id: proto.Int32(1)}, pb2.E_Scope_Label, "a"),
+			extended(&pb2.Extendable{Id: proto.Int32(1)}, pb2.E_Label, "b"),
+			extended(&pb2.Extendable{Id: proto.Int32(1)}, pb2.E_Count, int32(2)),
+			extended(&pb2.Extendable{}, pb2.E_Count, int32(1)),
+			extended(&pb2.Extendable{Id: proto.Int32(1)}, pb2.E_Tags, []string{"a", "b"}),
+			extended(&pb2.Extendable{Id: proto.Int32(1)}, pb2.E_Tags, []string{"b", "a"}),
+			extended(&pb2.Extendable{Id: proto.Int32(1)}, pb2.E_Detail, &pb2.Detail{Text: proto.String("a")}),
+			&pb2.Extendable{Id: proto.Int32(1), Child: &pb2.Extendable{}},
+			&pb2.Extendable{Id: proto.Int32(1), Child: extended(&pb2.Extendable{}, pb2.E_Label, "a")},
+		)
+	}
+
+	// Excluded extensions are left out of the hash.
+	tc.TestCase{
+		Protos: []proto.Message{
+			&pb2.Extendable{Id: proto.Int32(1)},
+			extended(&pb2.Extendable{Id: proto.Int32(1)}, pb2.E_Label, "a"),
+			extended(&pb2.Extendable{Id: proto.Int32(1)}, pb2.E_Detail, &pb2.Detail{Text: proto.String("a")}),
+		},
+	}.Check(t, with(ph.WithExtensions(ph.ExtensionsExcluded)))
+
+	// Extensions are identified by number by default, and by name with
+	// ExtensionsByName, as told by a copy of the extensions with label
+	// renumbered.
+	fdp := protodesc.ToFileDescriptorProto(pb2.File_tests_api_proto2_v1_extensions_proto)
+	for _, xd := range fdp.Extension {
+		if xd.GetName() == "label" {
+			xd.Number = proto.Int32(150)
+		}
+	}
+	fd, err := protodesc.NewFile(fdp, protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	renumbered := dynamicpb.NewMessage(fd.Messages().ByName("Extendable"))
+	renumbered.Set(renumbered.Descriptor().Fields().ByName("id"), protoreflect.ValueOfInt32(1))
+	renumbered.Set(dynamicpb.NewExtensionType(fd.Extensions().ByName("label")).TypeDescriptor(), protoreflect.ValueOfString("a"))
+	original := extended(&pb2.Extendable{Id: proto.Int32(1)}, pb2.E_Label, "a")
+	if hash(hasher, original) == hash(hasher, renumbered) {
+		t.Errorf("Renumbering an extension does not change the hash of its message by number")
+	}
+	byName := with(ph.WithExtensions(ph.ExtensionsByName))
+	if hash(byName, original) != hash(byName, renumbered) {
+		t.Errorf("Renumbering an extension changes the hash of its message by name")
+	}
+
+	// Extensions still held as unknown fields are hashed as extensions with a
+	// resolver, and as unknown fields otherwise.
+	b, err := proto.Marshal(extended(&pb2.Extendable{Id: proto.Int32(1)}, pb2.E_Label, "a", pb2.E_Detail, &pb2.Detail{Text: proto.String("b")}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	unresolved := &pb2.Extendable{}
+	if err := (proto.UnmarshalOptions{Resolver: new(protoregistry.Types)}).Unmarshal(b, unresolved); err != nil {
+		t.Fatal(err)
+	}
+	resolved := &pb2.Extendable{}
+	if err := proto.Unmarshal(b, resolved); err != nil {
+		t.Fatal(err)
+	}
+	if hash(hasher, unresolved) != hash(hasher, &pb2.Extendable{Id: proto.Int32(1)}) {
+		t.Errorf("Unresolved extensions are not ignored without a resolver")
+	}
+	tc.TestCase{
+		Protos: []proto.Message{unresolved, resolved},
+	}.Check(t, with(ph.WithExtensionResolver(protoregistry.GlobalTypes)))
+	tc.TestCase{
+		Protos: []proto.Message{unresolved, resolved},
+	}.Check(t, with(ph.WithExtensionResolver(protoregistry.GlobalTypes), ph.WithUnknownFields()))
+	tc.CheckDistinct(t, with(ph.WithUnknownFields()), unresolved, resolved)
+
+	// The resolver decodes the extensions of the wire format as well.
+	unknownTypes := with(ph.WithExtensionResolver(new(protoregistry.Types)))
+	h, err := unknownTypes.HashWire(resolved.ProtoReflect().Descriptor(), b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h != hash(unknownTypes, &pb2.Extendable{Id: proto.Int32(1)}) {
+		t.Errorf("HashWire decoded extensions its resolver does not know")
+	}
+}
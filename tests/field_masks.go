@@ -0,0 +1,131 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	ph "github.com/aserto-dev/go-protohash"
+	"github.com/aserto-dev/go-protohash/tests/api/v1"
+	tc "github.com/aserto-dev/go-protohash/tests/internal"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// TestFieldMasks performs tests on hashers created with opts and either
+// ph.WithFieldMask or ph.WithExcludePaths.
+func TestFieldMasks(t *testing.T, opts ...ph.HashOption) {
+
+	hasher := func(extra ...ph.HashOption) *ph.ProtoHasher {
+		return ph.New(append(append([]ph.HashOption(nil), opts...), extra...)...)
+	}
+	mask := func(paths ...string) ph.HashOption {
+		return ph.WithFieldMask(&fieldmaskpb.FieldMask{Paths: paths})
+	}
+
+	// Excluded fields are left out of the hash, wherever they are.
+	exclude := hasher(ph.WithExcludePaths(
+		"string_field",
+		"simple_field.string_field",
+		"repetitive_field.simple_field.string_field",
+	))
+	tc.TestCase{
+		Protos: []proto.Message{
+			&api.Simple{Int32Field: 1},
+			&api.Simple{Int32Field: 1, StringField: "a"},
+			&api.Simple{Int32Field: 1, StringField: "b"},
+		},
+	}.Check(t, exclude)
+	tc.TestCase{
+		Protos: []proto.Message{
+			&api.Simple{SimpleField: &api.Simple{BoolField: true}},
+			&api.Simple{SimpleField: &api.Simple{BoolField: true, StringField: "a"}},
+		},
+	}.Check(t, exclude)
+	tc.TestCase{
+		Protos: []proto.Message{
+			&api.Simple{RepetitiveField: &api.Repetitive{SimpleField: []*api.Simple{{BoolField: true}, {Int64Field: 2}}}},
+			&api.Simple{RepetitiveField: &api.Repetitive{SimpleField: []*api.Simple{{BoolField: true, StringField: "a"}, {Int64Field: 2, StringField: "b"}}}},
+		},
+	}.Check(t, exclude)
+	tc.CheckDistinct(t, exclude,
+		&api.Simple{SimpleField: &api.Simple{BoolField: true}},
+		&api.Simple{SimpleField: &api.Simple{BoolField: true, SimpleField: &api.Simple{StringField: "a"}}},
+		&api.Simple{SimpleField: &api.Simple{BoolField: true, SimpleField: &api.Simple{StringField: "b"}}},
+		&api.Simple{RepetitiveField: &api.Repetitive{StringField: []string{"a"}}},
+	)
+
+	// Paths apply to the values of maps.
+	maps := hasher(ph.WithExcludePaths("string_to_simple.string_field"))
+	tc.TestCase{
+		Protos: []proto.Message{
+			&api.StringMaps{StringToSimple: map[string]*api.Simple{"k": {BoolField: true}}},
+			&api.StringMaps{StringToSimple: map[string]*api.Simple{"k": {BoolField: true, StringField: "a"}}},
+		},
+	}.Check(t, maps)
+	tc.CheckDistinct(t, maps,
+		&api.StringMaps{StringToSimple: map[string]*api.Simple{"k": {BoolField: true}}},
+		&api.StringMaps{StringToSimple: map[string]*api.Simple{"l": {BoolField: true}}},
+	)
+
+	// Only the fields selected by a mask are hashed.
+	include := hasher(mask("int32_field", "simple_field.bool_field", "repetitive_field"))
+	tc.TestCase{
+		Protos: []proto.Message{
+			&api.Simple{Int32Field: 1, SimpleField: &api.Simple{BoolField: true}},
+			&api.Simple{Int32Field: 1, StringField: "a", SimpleField: &api.Simple{BoolField: true, StringField: "b"}},
+			&api.Simple{Int32Field: 1, SimpleField: &api.Simple{BoolField: true, SimpleField: &api.Simple{}}},
+		},
+	}.Check(t, include)
+	tc.CheckDistinct(t, include,
+		&api.Simple{Int32Field: 1},
+		&api.Simple{Int32Field: 2},
+		&api.Simple{Int32Field: 1, SimpleField: &api.Simple{BoolField: true}},
+		&api.Simple{Int32Field: 1, RepetitiveField: &api.Repetitive{StringField: []string{"a"}}},
+		&api.Simple{Int32Field: 1, RepetitiveField: &api.Repetitive{StringField: []string{"b"}}},
+	)
+
+	// Exclusions apply to the fields selected by a mask.
+	both := hasher(mask("simple_field"), ph.WithExcludePaths("simple_field.string_field"))
+	tc.TestCase{
+		Protos: []proto.Message{
+			&api.Simple{SimpleField: &api.Simple{Int32Field: 1}},
+			&api.Simple{StringField: "a", SimpleField: &api.Simple{Int32Field: 1, StringField: "b"}},
+		},
+	}.Check(t, both)
+
+	// Paths that do not match the hashed message are errors.
+	for _, paths := range [][]string{
+		{""},
+		{"no_field"},
+		{"simple_field.no_field"},
+		{"string_field.length"},
+		{"repetitive_field.string_field.length"},
+		{"simple_field", "simple_field.no_field"},
+		{"simple_field.no_field", "simple_field"},
+	} {
+		for _, h := range []*ph.ProtoHasher{hasher(mask(paths...)), hasher(ph.WithExcludePaths(paths...))} {
+			msg := &api.Simple{}
+			_, err := h.HashMessage(msg)
+			if !errors.Is(err, ph.ErrInvalidPath) {
+				t.Errorf("Hashing with the paths %q returned %v instead of an invalid path error", paths, err)
+			}
+			_, err = h.HashMessageDigest(msg)
+			if !errors.Is(err, ph.ErrInvalidPath) {
+				t.Errorf("Digesting with the paths %q returned %v instead of an invalid path error", paths, err)
+			}
+			_, err = h.HashWire(msg.ProtoReflect().Descriptor(), nil)
+			if !errors.Is(err, ph.ErrInvalidPath) {
+				t.Errorf("Hashing the wire format with the paths %q returned %v instead of an invalid path error", paths, err)
+			}
+		}
+	}
+
+	// Paths cannot go into well-known types, which are hashed as a whole.
+	_, err := hasher(ph.WithExcludePaths("timestamp_field.nanos")).HashMessage(&api.KnownTypes{})
+	if !errors.Is(err, ph.ErrInvalidPath) {
+		t.Errorf("Hashing with a path into a well-known type returned %v instead of an invalid path error", err)
+	}
+	if _, err := hasher(ph.WithExcludePaths("timestamp_field")).HashMessage(&api.KnownTypes{}); err != nil {
+		t.Errorf("Hashing with a path to a well-known type returned an error: %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+package tests
+
+import (
+	"testing"
+
+	ph "github.com/aserto-dev/go-protohash"
+	"github.com/aserto-dev/go-protohash/tests/api/v1"
+	ti "github.com/aserto-dev/go-protohash/tests/internal"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestFieldNumbers checks that equal values held in different fields do not
+// collide. It is only meaningful for hashers using ph.AlgorithmV2.
+func TestFieldNumbers(t *testing.T, hasher *ph.ProtoHasher) {
+
+	testCases := [][]proto.Message{
+		//////////////////////////////////////
+		//  Same value in different fields. //
+		//////////////////////////////////////
+		{
+			&api.Simple{Int32Field: 1},
+			&api.Simple{Int64Field: 1},
+			&api.Simple{Sint32Field: 1},
+			&api.Simple{Sfixed64Field: 1},
+		},
+
+		{
+			&api.Simple{Fixed32Field: 1},
+			&api.Simple{Uint32Field: 1},
+			&api.Simple{Uint64Field: 1},
+		},
+
+		{
+			&api.Simple{StringField: "foo"},
+			&api.Repetitive{StringField: []string{"foo"}},
+			&api.Repetitive{BytesField: [][]byte{[]byte("foo")}},
+		},
+
+		{
+			&api.Simple{SimpleField: &api.Simple{}},
+			&api.Simple{RepetitiveField: &api.Repetitive{}},
+			&api.Simple{SingletonField: &api.Singleton{}},
+		},
+
+		////////////////////////////////////////
+		//  Different arms of the same oneof. //
+		////////////////////////////////////////
+		{
+			&api.Singleton{Singleton: &api.Singleton_TheInt32{}},
+			&api.Singleton{Singleton: &api.Singleton_TheInt64{}},
+			&api.Singleton{Singleton: &api.Singleton_TheSint32{}},
+			&api.Singleton{Singleton: &api.Singleton_TheSint64{}},
+			&api.Singleton{Singleton: &api.Singleton_TheSfixed32{}},
+			&api.Singleton{Singleton: &api.Singleton_TheSfixed64{}},
+		},
+
+		{
+			&api.Singleton{Singleton: &api.Singleton_TheFixed32{TheFixed32: 7}},
+			&api.Singleton{Singleton: &api.Singleton_TheFixed64{TheFixed64: 7}},
+			&api.Singleton{Singleton: &api.Singleton_TheUint32{TheUint32: 7}},
+			&api.Singleton{Singleton: &api.Singleton_TheUint64{TheUint64: 7}},
+		},
+
+		//////////////////////
+		//  Swapped values. //
+		//////////////////////
+		{
+			&api.Simple{Int32Field: 1, Int64Field: 2},
+			&api.Simple{Int32Field: 2, Int64Field: 1},
+		},
+	}
+
+	for _, protos := range testCases {
+		ti.CheckDistinct(t, hasher, protos...)
+	}
+}
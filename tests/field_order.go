@@ -0,0 +1,85 @@
+package tests
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	ph "github.com/aserto-dev/go-protohash"
+	"github.com/aserto-dev/go-protohash/tests/api/v1"
+	ti "github.com/aserto-dev/go-protohash/tests/internal"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// TestFieldOrder checks that the hash of a message does not depend on the order
+// in which protoreflect.Message.Range yields its fields.
+func TestFieldOrder(t *testing.T, hasher *ph.ProtoHasher) {
+
+	reorderings := map[string]func([]protoreflect.FieldDescriptor){
+		"reversed": func(fds []protoreflect.FieldDescriptor) {
+			for i, j := 0, len(fds)-1; i < j; i, j = i+1, j-1 {
+				fds[i], fds[j] = fds[j], fds[i]
+			}
+		},
+		"by name": func(fds []protoreflect.FieldDescriptor) {
+			sort.Slice(fds, func(i, j int) bool { return fds[i].Name() < fds[j].Name() })
+		},
+		"shuffled": func(fds []protoreflect.FieldDescriptor) {
+			r := rand.New(rand.NewSource(int64(len(fds))))
+			r.Shuffle(len(fds), func(i, j int) { fds[i], fds[j] = fds[j], fds[i] })
+		},
+	}
+
+	testCases := []proto.Message{
+		&api.Simple{
+			BoolField:   true,
+			BytesField:  []byte("bytes"),
+			DoubleField: 1.5,
+			Int32Field:  -32,
+			Int64Field:  64,
+			StringField: "string",
+			Uint64Field: 99,
+			SimpleField: &api.Simple{
+				FloatField:     2.5,
+				Sint32Field:    -7,
+				StringField:    "nested",
+				SingletonField: &api.Singleton{Singleton: &api.Singleton_TheString{TheString: "oneof"}},
+			},
+		},
+
+		&api.Repetitive{
+			BoolField:   []bool{true, false},
+			Int32Field:  []int32{3, 2, 1},
+			StringField: []string{"a", "b", "c"},
+			SimpleField: []*api.Simple{{Int32Field: 1}, {StringField: "two"}},
+		},
+
+		&api.StringMaps{
+			StringToBool:   map[string]bool{"t": true},
+			StringToInt64:  map[string]int64{"one": 1, "two": 2},
+			StringToString: map[string]string{"foo": "bar"},
+			StringToSimple: map[string]*api.Simple{"simple": {BoolField: true, Int64Field: 10}},
+		},
+	}
+
+	for _, message := range testCases {
+		expected, err := hasher.HashMessage(message)
+		if err != nil {
+			t.Fatalf("Attempting to hash %T{ %[1]v } returned an error: %v", message, err)
+		}
+
+		for name, reorder := range reorderings {
+			actual, err := hasher.HashMessage(ti.ReorderFields(message, reorder))
+			if err != nil {
+				t.Errorf("Attempting to hash %T{ %[1]v } (%s) returned an error: %v", message, name, err)
+				continue
+			}
+
+			if actual != expected {
+				t.Errorf("The objecthash for %T{ %[1]v } changed when its fields were ranged %s.\n"+
+					"Actual:   %x\nExpected: %x\n", message, name, actual, expected)
+			}
+		}
+	}
+}
@@ -19,9 +19,20 @@ import (
 	"testing"
 
 	ph "github.com/aserto-dev/go-protohash"
+	pb2 "github.com/aserto-dev/go-protohash/tests/api/proto2/v1"
 	"github.com/aserto-dev/go-protohash/tests/api/v1"
 	ti "github.com/aserto-dev/go-protohash/tests/internal"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var (
+	negativeZero = math.Copysign(0, -1)
+
+	// Other NaNs than math.NaN: with another payload, negative, and signaling.
+	payloadNaN   = math.Float64frombits(0x7ff8000000000002)
+	negativeNaN  = math.Float64frombits(0xfff8000000000001)
+	signalingNaN = math.Float64frombits(0x7ff0000000000001)
 )
 
 // TestFloatFields performs tests on how floating point numbers are handled.
@@ -37,9 +48,11 @@ func TestFloatFields(t *testing.T, hasher *ph.ProtoHasher) {
 
 				&api.FloatMessage{Values: []float32{-2, -1, 0, 1, 2}},
 			},
-			EquivalentObject:     map[string][]float64{"values": {-2, -1, 0, 1, 2}},
-			EquivalentJSONString: "{\"values\": [-2, -1, 0, 1, 2]}",
-			ExpectedHashString:   "3df0da89a348c288",
+			EquivalentObject:         map[string][]float64{"values": {-2, -1, 0, 1, 2}},
+			EquivalentJSONString:     "{\"values\": [-2, -1, 0, 1, 2]}",
+			ExpectedHashString:       "3df0da89a348c288",
+			ExpectedObjectHashString: "586202dddb0e98bb8ce0b7289e29a9f7397b9b1996f3f8fe788f4cfb230b7ee8",
+			ExpectedHashStringV2:     "4a3e16b65d40b931",
 		},
 
 		// Note that due to how floating point numbers work, we have to carefully
@@ -51,9 +64,11 @@ func TestFloatFields(t *testing.T, hasher *ph.ProtoHasher) {
 
 				&api.FloatMessage{Values: []float32{0.0078125, 7.888609052210118e-31}},
 			},
-			EquivalentObject:     map[string][]float64{"values": {0.0078125, 7.888609052210118e-31}},
-			EquivalentJSONString: "{\"values\": [0.0078125, 7.888609052210118e-31]}",
-			ExpectedHashString:   "ad21263b80785c33",
+			EquivalentObject:         map[string][]float64{"values": {0.0078125, 7.888609052210118e-31}},
+			EquivalentJSONString:     "{\"values\": [0.0078125, 7.888609052210118e-31]}",
+			ExpectedHashString:       "ad21263b80785c33",
+			ExpectedObjectHashString: "7b7cba0ed312bc6611f0523e7c46ce9a2ed9ecb798eb80e1cdf93c95faf503c7",
+			ExpectedHashStringV2:     "9083b060b0ec3133",
 		},
 
 		{
@@ -62,9 +77,11 @@ func TestFloatFields(t *testing.T, hasher *ph.ProtoHasher) {
 
 				&api.FloatMessage{Values: []float32{-1.0, 1.5, 1000.000244140625, 1267650600228229401496703205376, 32.0, 13.0009765625}},
 			},
-			EquivalentObject:     map[string][]float64{"values": {-1.0, 1.5, 1000.000244140625, 1267650600228229401496703205376, 32.0, 13.0009765625}},
-			EquivalentJSONString: "{\"values\": [-1.0, 1.5, 1000.000244140625, 1267650600228229401496703205376, 32.0, 13.0009765625]}",
-			ExpectedHashString:   "780f95ae6c09ac4f",
+			EquivalentObject:         map[string][]float64{"values": {-1.0, 1.5, 1000.000244140625, 1267650600228229401496703205376, 32.0, 13.0009765625}},
+			EquivalentJSONString:     "{\"values\": [-1.0, 1.5, 1000.000244140625, 1267650600228229401496703205376, 32.0, 13.0009765625]}",
+			ExpectedHashString:       "780f95ae6c09ac4f",
+			ExpectedObjectHashString: "ac261ff3d8b933998e3fea278539eb40b15811dd835d224e0150dce4794168b7",
+			ExpectedHashStringV2:     "1c3b6c2e6d85ab25",
 		},
 
 		/////////////////////////////////////////////////////////////////
@@ -78,9 +95,11 @@ func TestFloatFields(t *testing.T, hasher *ph.ProtoHasher) {
 				// However, float32 "0.1" is equal to float64 "1.0000000149011612e-1".
 				&api.DoubleMessage{Value: 1.0000000149011612e-1},
 			},
-			EquivalentObject:     map[string]float32{"value": 0.1},
-			EquivalentJSONString: "{\"value\": 1.0000000149011612e-1}", // JSON objecthash only uses 64-bit floats.
-			ExpectedHashString:   "4b12cde041073c56",
+			EquivalentObject:         map[string]float32{"value": 0.1},
+			EquivalentJSONString:     "{\"value\": 1.0000000149011612e-1}", // JSON objecthash only uses 64-bit floats.
+			ExpectedHashString:       "4b12cde041073c56",
+			ExpectedObjectHashString: "7081ed6a1e7ad8e7f981a2894a3bd6d3b0b0033b69c03cce84b61dd063f4efaa",
+			ExpectedHashStringV2:     "55ea900338a66e29",
 		},
 
 		// There's no float32 number that is equivalent to a float64 "0.1".
@@ -88,9 +107,11 @@ func TestFloatFields(t *testing.T, hasher *ph.ProtoHasher) {
 			Protos: []proto.Message{
 				&api.DoubleMessage{Value: 0.1},
 			},
-			EquivalentObject:     map[string]float64{"value": 0.1},
-			EquivalentJSONString: "{\"value\": 0.1}",
-			ExpectedHashString:   "3703e1c494c5c8e9",
+			EquivalentObject:         map[string]float64{"value": 0.1},
+			EquivalentJSONString:     "{\"value\": 0.1}",
+			ExpectedHashString:       "3703e1c494c5c8e9",
+			ExpectedObjectHashString: "e175fbe785bae88b598d3ecaad8a64d2a998e9f673173a226868f2ef312a5225",
+			ExpectedHashStringV2:     "3407bfedf4a21710",
 		},
 
 		{
@@ -100,9 +121,11 @@ func TestFloatFields(t *testing.T, hasher *ph.ProtoHasher) {
 				// The decimal representation of the equivalent 64-bit float is different.
 				&api.DoubleMessage{Value: 1.2163543234531120e+25},
 			},
-			EquivalentObject:     map[string]float32{"value": 1.2163543e+25},
-			EquivalentJSONString: "{\"value\": 1.2163543234531120e+25}", // JSON objecthash only uses 64-bit floats.
-			ExpectedHashString:   "8cf0a1724e22238c",
+			EquivalentObject:         map[string]float32{"value": 1.2163543e+25},
+			EquivalentJSONString:     "{\"value\": 1.2163543234531120e+25}", // JSON objecthash only uses 64-bit floats.
+			ExpectedHashString:       "8cf0a1724e22238c",
+			ExpectedObjectHashString: "bbb17cf7312f2ba5b0002d781f16d1ab50c3d25dc044ed3428750826a1c68653",
+			ExpectedHashStringV2:     "c28e91d102c8c367",
 		},
 
 		// There's no float32 number that is equivalent to a float64 "1e+25".
@@ -110,9 +133,11 @@ func TestFloatFields(t *testing.T, hasher *ph.ProtoHasher) {
 			Protos: []proto.Message{
 				&api.DoubleMessage{Value: 1e+25},
 			},
-			EquivalentObject:     map[string]float64{"value": 1e+25},
-			EquivalentJSONString: "{\"value\": 1e+25}",
-			ExpectedHashString:   "b0c41f10e61fe56a",
+			EquivalentObject:         map[string]float64{"value": 1e+25},
+			EquivalentJSONString:     "{\"value\": 1e+25}",
+			ExpectedHashString:       "b0c41f10e61fe56a",
+			ExpectedObjectHashString: "874beabbede24974a9f3f74e3448670e0c42c0aaba082f18b963b72253649362",
+			ExpectedHashStringV2:     "56e96949ffa176b3",
 		},
 
 		//////////////////////
@@ -134,7 +159,9 @@ func TestFloatFields(t *testing.T, hasher *ph.ProtoHasher) {
 			EquivalentObject: map[string]float64{"value": math.NaN()},
 			// No equivalent JSON: JSON does not support special float values.
 			// See: https://tools.ietf.org/html/rfc4627#section-2.4
-			ExpectedHashString: "e1ccffaf73d7415e",
+			ExpectedHashString:       "e1ccffaf73d7415e",
+			ExpectedObjectHashString: "16614de29b0823c41cabc993fa6c45da87e4e74c5d836edbcddcfaaf06ffafd1",
+			ExpectedHashStringV2:     "bc07accdbe310c47",
 		},
 		{
 			Protos: []proto.Message{
@@ -143,7 +170,9 @@ func TestFloatFields(t *testing.T, hasher *ph.ProtoHasher) {
 			EquivalentObject: map[string]float64{"value": math.NaN()},
 			// No equivalent JSON: JSON does not support special float values.
 			// See: https://tools.ietf.org/html/rfc4627#section-2.4
-			ExpectedHashString: "93d5337cc141c0de",
+			ExpectedHashString:       "93d5337cc141c0de",
+			ExpectedObjectHashString: "16614de29b0823c41cabc993fa6c45da87e4e74c5d836edbcddcfaaf06ffafd1",
+			ExpectedHashStringV2:     "fd43311ff1e69a7b",
 		},
 
 		{
@@ -155,7 +184,9 @@ func TestFloatFields(t *testing.T, hasher *ph.ProtoHasher) {
 			EquivalentObject: map[string]float64{"value": math.Inf(1)},
 			// No equivalent JSON: JSON does not support special float values.
 			// See: https://tools.ietf.org/html/rfc4627#section-2.4
-			ExpectedHashString: "989db45457ac5739",
+			ExpectedHashString:       "989db45457ac5739",
+			ExpectedObjectHashString: "c58cd512e86204e99cb6c11d83bb3daaccdd946e66383004cb9b7f87f762935c",
+			ExpectedHashStringV2:     "dca06367bcab7ca3",
 		},
 
 		{
@@ -167,11 +198,157 @@ func TestFloatFields(t *testing.T, hasher *ph.ProtoHasher) {
 			EquivalentObject: map[string]float64{"value": math.Inf(-1)},
 			// No equivalent JSON: JSON does not support special float values.
 			// See: https://tools.ietf.org/html/rfc4627#section-2.4
-			ExpectedHashString: "1057aef55fca7774",
+			ExpectedHashString:       "1057aef55fca7774",
+			ExpectedObjectHashString: "1a4ffd7e9dc1f915c5b3b821d9194ac7d6d2bdec947aa8c3b3b1e9017c651331",
+			ExpectedHashStringV2:     "482974affff58db4",
 		},
 	}
 
 	for _, tc := range testCases {
 		tc.Check(t, hasher)
 	}
+
+	// Floats hash by their bits: NaN payloads and the sign of zero are told
+	// apart, and -0 is a populated proto3 value.
+	ti.CheckDistinct(t, hasher,
+		&api.DoubleMessage{},
+		&api.DoubleMessage{Value: negativeZero},
+		&api.DoubleMessage{Value: math.NaN()},
+		&api.DoubleMessage{Value: payloadNaN},
+		&api.DoubleMessage{Value: negativeNaN},
+	)
+}
+
+// TestCanonicalFloats performs tests on a hasher created with
+// ph.WithCanonicalFloats.
+func TestCanonicalFloats(t *testing.T, hasher *ph.ProtoHasher) {
+
+	testCases := []ti.TestCase{
+		// -0 is unset in proto3, as +0 is.
+		{
+			Protos: []proto.Message{
+				&api.DoubleMessage{},
+				&api.DoubleMessage{Value: negativeZero},
+				&api.FloatMessage{Value: float32(negativeZero)},
+			},
+			ExpectedHashString:   "0",
+			ExpectedHashStringV2: "0",
+		},
+
+		// All NaNs hash like math.NaN.
+		{
+			Protos: []proto.Message{
+				&api.DoubleMessage{Value: math.NaN()},
+				&api.DoubleMessage{Value: payloadNaN},
+				&api.DoubleMessage{Value: negativeNaN},
+				&api.DoubleMessage{Value: signalingNaN},
+				&api.FloatMessage{Value: float32(math.NaN())},
+				&api.FloatMessage{Value: math.Float32frombits(0xffc00000)},
+			},
+			ExpectedHashString:   "e1ccffaf73d7415e",
+			ExpectedHashStringV2: "bc07accdbe310c47",
+		},
+
+		// Elements of lists are canonicalized as well.
+		{
+			Protos: []proto.Message{
+				&api.DoubleMessage{Values: []float64{0, math.NaN(), 1}},
+				&api.DoubleMessage{Values: []float64{negativeZero, negativeNaN, 1}},
+				&api.FloatMessage{Values: []float32{float32(negativeZero), float32(payloadNaN), 1}},
+			},
+		},
+
+		// Fields with explicit presence are still populated when set to -0, and
+		// hash like +0.
+		{
+			Protos: []proto.Message{
+				&pb2.Simple{DoubleField: proto.Float64(0)},
+				&pb2.Simple{DoubleField: proto.Float64(negativeZero)},
+			},
+		},
+		{
+			Protos: []proto.Message{
+				&api.KnownTypes{DoubleValueField: wrapperspb.Double(0)},
+				&api.KnownTypes{DoubleValueField: wrapperspb.Double(negativeZero)},
+			},
+		},
+		{
+			Protos: []proto.Message{
+				&api.KnownTypes{DoubleValueField: wrapperspb.Double(math.NaN())},
+				&api.KnownTypes{DoubleValueField: wrapperspb.Double(signalingNaN)},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc.Check(t, hasher)
+	}
+
+	// Canonical values still differ from the others.
+	ti.CheckDistinct(t, hasher,
+		&api.DoubleMessage{},
+		&api.DoubleMessage{Value: math.NaN()},
+		&api.DoubleMessage{Value: math.Inf(1)},
+		&api.DoubleMessage{Value: math.Inf(-1)},
+		&api.DoubleMessage{Value: math.SmallestNonzeroFloat64},
+		&api.DoubleMessage{Value: -math.SmallestNonzeroFloat64},
+	)
+	ti.CheckDistinct(t, hasher,
+		&pb2.Simple{},
+		&pb2.Simple{DoubleField: proto.Float64(negativeZero)},
+	)
+}
+
+// TestFloat32Bits performs tests on a hasher created with ph.WithFloat32Bits.
+func TestFloat32Bits(t *testing.T, hasher *ph.ProtoHasher) {
+
+	testCases := []ti.TestCase{
+		// Doubles hash as they do without the option.
+		{
+			Protos: []proto.Message{
+				&api.DoubleMessage{Value: 0.1},
+			},
+			ExpectedHashString:   "3703e1c494c5c8e9",
+			ExpectedHashStringV2: "3407bfedf4a21710",
+		},
+		{
+			Protos: []proto.Message{
+				&api.FloatMessage{Value: 0.1, Values: []float32{-1, 1.5, float32(math.Inf(1))}},
+			},
+		},
+		{
+			Protos: []proto.Message{
+				&api.KnownTypes{FloatValueField: wrapperspb.Float(1.5)},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc.Check(t, hasher)
+	}
+
+	// Floats no longer hash like the equal doubles, so that changing the type
+	// of a field from float to double changes the hash.
+	ti.CheckDistinct(t, hasher,
+		&api.FloatMessage{Value: 1.5},
+		&api.DoubleMessage{Value: 1.5},
+	)
+	ti.CheckDistinct(t, hasher,
+		&api.FloatMessage{Values: []float32{-2, -1, 1, 2}},
+		&api.DoubleMessage{Values: []float64{-2, -1, 1, 2}},
+	)
+
+	// NaNs and -0 are canonicalized as float32 values.
+	canonical := ph.New(ph.WithAlgorithm(hasher.Algorithm()), ph.WithFloat32Bits(), ph.WithCanonicalFloats())
+	ti.TestCase{
+		Protos: []proto.Message{
+			&api.FloatMessage{Values: []float32{float32(math.NaN()), 0}},
+			&api.FloatMessage{Values: []float32{math.Float32frombits(0xffc00000), float32(negativeZero)}},
+			&api.FloatMessage{Values: []float32{math.Float32frombits(0x7fc00002), float32(negativeZero)}},
+		},
+	}.Check(t, canonical)
+	ti.CheckDistinct(t, canonical,
+		&api.FloatMessage{Value: float32(math.NaN())},
+		&api.DoubleMessage{Value: math.NaN()},
+	)
 }
@@ -0,0 +1,110 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	ph "github.com/aserto-dev/go-protohash"
+	"github.com/aserto-dev/go-protohash/tests/api/v1"
+	tc "github.com/aserto-dev/go-protohash/tests/internal"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// TestFloatPrecision performs tests on hashers created with opts and
+// ph.WithFloatPrecision.
+func TestFloatPrecision(t *testing.T, opts ...ph.HashOption) {
+
+	hasher := func(extra ...ph.HashOption) *ph.ProtoHasher {
+		return ph.New(append(append([]ph.HashOption(nil), opts...), extra...)...)
+	}
+
+	// Values are rounded to significant digits, and hash like the rounded
+	// value does without quantization.
+	digits := hasher(ph.WithFloatPrecision(ph.SignificantDigits(6)))
+	tc.TestCase{
+		Protos: []proto.Message{
+			&api.DoubleMessage{Value: 0.1},
+			&api.DoubleMessage{Value: 0.1000001},
+			&api.DoubleMessage{Value: 0.09999996},
+			&api.FloatMessage{Value: 0.1},
+		},
+		ExpectedHashString:   "3703e1c494c5c8e9",
+		ExpectedHashStringV2: "3407bfedf4a21710",
+	}.Check(t, digits)
+	tc.TestCase{
+		Protos: []proto.Message{
+			&api.DoubleMessage{Values: []float64{0.3, 1234570, -2.5e-300}},
+			&api.DoubleMessage{Values: []float64{0.1 + 0.2, 1234567, -2.500001e-300}},
+		},
+	}.Check(t, digits)
+	tc.TestCase{
+		Protos: []proto.Message{
+			&api.KnownTypes{
+				DoubleValueField: wrapperspb.Double(0.3),
+				FloatValueField:  wrapperspb.Float(1.5),
+				StructField:      &structpb.Struct{Fields: map[string]*structpb.Value{"n": structpb.NewNumberValue(0.3)}},
+			},
+			&api.KnownTypes{
+				DoubleValueField: wrapperspb.Double(0.1 + 0.2),
+				FloatValueField:  wrapperspb.Float(1.5000001),
+				StructField:      &structpb.Struct{Fields: map[string]*structpb.Value{"n": structpb.NewNumberValue(0.1 + 0.2)}},
+			},
+		},
+	}.Check(t, digits)
+	tc.CheckDistinct(t, digits,
+		&api.DoubleMessage{Value: 1234560},
+		&api.DoubleMessage{Value: 1234570},
+		&api.DoubleMessage{Value: math.MaxFloat64},
+		&api.DoubleMessage{Value: math.Inf(1)},
+		&api.DoubleMessage{Value: math.NaN()},
+	)
+
+	// Values are rounded to multiples of a step, ties to even, and values
+	// rounded to zero hash as +0.
+	step := hasher(ph.WithFloatPrecision(ph.DecimalStep(0.5)))
+	tc.TestCase{
+		Protos: []proto.Message{
+			&api.DoubleMessage{Values: []float64{0, 1, 1, 2}},
+			&api.DoubleMessage{Values: []float64{0.2, 1.2, 0.75, 1.75}},
+			&api.DoubleMessage{Values: []float64{-0.25, 0.8, 1.25, 2.2}},
+		},
+	}.Check(t, step)
+	tc.CheckDistinct(t, step,
+		&api.DoubleMessage{Value: 1},
+		&api.DoubleMessage{Value: 1.5},
+		&api.DoubleMessage{Value: 1e300},
+		&api.DoubleMessage{Value: 1e300 * (1 + 1e-15)},
+	)
+
+	// A precision given for a field applies to that field only, and overrides
+	// the global one.
+	field := hasher(
+		ph.WithFloatPrecision(ph.SignificantDigits(17)),
+		ph.WithFloatPrecision(ph.DecimalStep(0.01), "tests.api.v1.DoubleMessage.value"),
+	)
+	tc.TestCase{
+		Protos: []proto.Message{
+			&api.DoubleMessage{Value: 1, Values: []float64{1.001}},
+			&api.DoubleMessage{Value: 1.001, Values: []float64{1.001}},
+		},
+	}.Check(t, field)
+	tc.CheckDistinct(t, field,
+		&api.DoubleMessage{Values: []float64{1}},
+		&api.DoubleMessage{Values: []float64{1.001}},
+	)
+
+	// Invalid precisions make hashing fail.
+	for _, p := range []ph.FloatPrecision{
+		ph.SignificantDigits(0),
+		ph.SignificantDigits(18),
+		ph.DecimalStep(-1),
+		ph.DecimalStep(math.Inf(1)),
+		ph.DecimalStep(math.NaN()),
+	} {
+		if _, err := hasher(ph.WithFloatPrecision(p)).HashMessage(&api.DoubleMessage{}); err == nil {
+			t.Errorf("Hashing with the invalid precision %+v did not fail", p)
+		}
+	}
+}
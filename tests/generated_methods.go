@@ -0,0 +1,106 @@
+package tests
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	ph "github.com/aserto-dev/go-protohash"
+	protohashpb "github.com/aserto-dev/go-protohash/protohash"
+	_ "github.com/aserto-dev/go-protohash/tests/api/proto2/v1"
+	_ "github.com/aserto-dev/go-protohash/tests/api/v1"
+	_ "github.com/aserto-dev/go-protohash/tests/api/v2"
+	_ "github.com/aserto-dev/go-protohash/tests/api/v3"
+	ti "github.com/aserto-dev/go-protohash/tests/internal"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// TestGeneratedMethods checks that every message of tests/api has a generated
+// ProtoHash method, unless it declares hashing options or extension ranges, and
+// that hasher, which uses it, gives the same results as reflected, which was
+// created with ph.WithReflectionOnly, for both hashes and digests.
+func TestGeneratedMethods(t *testing.T, hasher, reflected *ph.ProtoHasher) {
+
+	var messages []protoreflect.MessageType
+	protoregistry.GlobalTypes.RangeMessages(func(mt protoreflect.MessageType) bool {
+		if strings.HasPrefix(string(mt.Descriptor().FullName()), "tests.api.") {
+			messages = append(messages, mt)
+		}
+		return true
+	})
+	if len(messages) == 0 {
+		t.Fatal("No message found in tests/api")
+	}
+
+	for _, mt := range messages {
+		_, ok := mt.New().Interface().(ph.Hashable)
+		switch {
+		case !hasGeneratedMethod(mt.Descriptor()) && ok:
+			t.Errorf("%s has a generated ProtoHash method despite its hashing options or extension ranges", mt.Descriptor().FullName())
+		case hasGeneratedMethod(mt.Descriptor()) && !ok:
+			t.Errorf("%s has no generated ProtoHash method", mt.Descriptor().FullName())
+			continue
+		}
+
+		for seed := 0; seed < 6; seed++ {
+			message := mt.New()
+			ti.Populate(message, seed, 2)
+
+			expected, err := reflected.HashMessage(message.Interface())
+			if err != nil {
+				t.Errorf("Attempting to hash %T{ %[1]v } returned an error: %v", message.Interface(), err)
+				continue
+			}
+
+			actual, err := hasher.HashMessage(message.Interface())
+			if err != nil {
+				t.Errorf("Attempting to hash %T{ %[1]v } returned an error: %v", message.Interface(), err)
+				continue
+			}
+
+			if actual != expected {
+				t.Errorf("The generated hash of %T{ %[1]v } differs from its reflected hash.\n"+
+					"Actual:   %x\nExpected: %x\n", message.Interface(), actual, expected)
+			}
+
+			expectedDigest, err := reflected.HashMessageDigest(message.Interface())
+			if err != nil {
+				t.Errorf("Attempting to digest %T{ %[1]v } returned an error: %v", message.Interface(), err)
+				continue
+			}
+
+			actualDigest, err := hasher.HashMessageDigest(message.Interface())
+			if err != nil {
+				t.Errorf("Attempting to digest %T{ %[1]v } returned an error: %v", message.Interface(), err)
+				continue
+			}
+
+			if !bytes.Equal(actualDigest, expectedDigest) {
+				t.Errorf("The generated digest of %T{ %[1]v } differs from its reflected digest.\n"+
+					"Actual:   %x\nExpected: %x\n", message.Interface(), actualDigest, expectedDigest)
+			}
+		}
+	}
+}
+
+// hasGeneratedMethod reports whether protoc-gen-go-protohash generates a
+// ProtoHash method for the messages described by md.
+func hasGeneratedMethod(md protoreflect.MessageDescriptor) bool {
+	return !hasHashingOptions(md) && md.ExtensionRanges().Len() == 0
+}
+
+// hasHashingOptions reports whether md or one of its fields declares options
+// from protohash/options.proto.
+func hasHashingOptions(md protoreflect.MessageDescriptor) bool {
+	if proto.HasExtension(md.Options(), protohashpb.E_Message) {
+		return true
+	}
+	for i := 0; i < md.Fields().Len(); i++ {
+		if proto.HasExtension(md.Fields().Get(i).Options(), protohashpb.E_Field) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package tests
+
+import (
+	"testing"
+
+	ph "github.com/aserto-dev/go-protohash"
+	"github.com/aserto-dev/go-protohash/tests/api/v1"
+	tc "github.com/aserto-dev/go-protohash/tests/internal"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestHashingOptions performs tests on the hashing options declared in
+// protohash/options.proto.
+func TestHashingOptions(t *testing.T, hasher *ph.ProtoHasher) {
+
+	// "é" as a single code point, and as "e" followed by a combining accent.
+	const composed, decomposed = "\u00e9t\u00e9", "e\u0301te\u0301"
+
+	testCases := []tc.TestCase{
+		// Ignored fields are left out of the hash.
+		{
+			Protos: []proto.Message{
+				&api.Annotated{Id: "id"},
+				&api.Annotated{Id: "id", Etag: "1"},
+				&api.Annotated{Id: "id", Etag: "2"},
+			},
+		},
+
+		// Unordered fields are hashed as sets or multisets.
+		{
+			Protos: []proto.Message{
+				&api.Annotated{Tags: []string{"a", "b"}},
+				&api.Annotated{Tags: []string{"b", "a", "b"}},
+			},
+		},
+		{
+			Protos: []proto.Message{
+				&api.Annotated{Labels: []string{"a", "b", "a"}},
+				&api.Annotated{Labels: []string{"a", "a", "b"}},
+			},
+		},
+
+		// Normalized strings are hashed in Unicode Normalization Form C, in
+		// singular fields, lists and maps.
+		{
+			Protos: []proto.Message{
+				&api.Annotated{Name: composed, Aliases: []string{composed}, Attributes: map[string]string{composed: composed}},
+				&api.Annotated{Name: decomposed, Aliases: []string{decomposed}, Attributes: map[string]string{decomposed: decomposed}},
+			},
+		},
+
+		// Options apply to nested messages as well.
+		{
+			Protos: []proto.Message{
+				&api.Annotated{Child: &api.Annotated{Id: "child", Etag: "1", Tags: []string{"a", "b"}}},
+				&api.Annotated{Child: &api.Annotated{Id: "child", Etag: "2", Tags: []string{"b", "a"}}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc.Check(t, hasher)
+	}
+
+	tc.CheckDistinct(t, hasher,
+		// Fields without options are not affected.
+		&api.Annotated{Id: composed},
+		&api.Annotated{Id: decomposed},
+
+		// Multisets count duplicates, unlike sets.
+		&api.Annotated{Labels: []string{"a", "b"}},
+		&api.Annotated{Labels: []string{"a", "b", "b"}},
+		&api.Annotated{Tags: []string{"a"}},
+		&api.Annotated{Tags: []string{"b"}},
+	)
+
+	// Messages declared with include_type_name do not hash like messages of
+	// other types holding the same fields, even when they are empty.
+	tc.CheckDistinct(t, hasher,
+		&api.TypedName{Value: "value"},
+		&api.TypedLabel{Value: "value"},
+		&api.UntypedName{Value: "value"},
+	)
+	tc.CheckDistinct(t, hasher,
+		&api.TypedName{},
+		&api.TypedLabel{},
+		&api.UntypedName{},
+	)
+}
@@ -45,7 +45,9 @@ func TestIntegerFields(t *testing.T, hasher *ph.ProtoHasher) {
 			},
 			EquivalentObject: map[string][]int32{"values": {0, 1, 2}},
 			// No equivalent JSON: JSON does not have an "integer" type. All numbers are floats.
-			ExpectedHashString: "cc2ab53c181c4329",
+			ExpectedHashString:       "cc2ab53c181c4329",
+			ExpectedObjectHashString: "42794fb0e73c2b5f427aa76486555d07589359054848396ddf173e9e0b4ab931",
+			ExpectedHashStringV2:     "abc9b1b6745f88e6",
 		},
 
 		{
@@ -59,7 +61,9 @@ func TestIntegerFields(t *testing.T, hasher *ph.ProtoHasher) {
 			},
 			EquivalentObject: map[string][]int32{"values": {-2, -1, 0, 1, 2}},
 			// No equivalent JSON: JSON does not have an "integer" type. All numbers are floats.
-			ExpectedHashString: "8e40d97221a0dba3",
+			ExpectedHashString:       "8e40d97221a0dba3",
+			ExpectedObjectHashString: "6cb613a53b6086b88dbda40b30e902adb41288b0b1f7a627905beaa764ee49cb",
+			ExpectedHashStringV2:     "dc7370ee87dfad77",
 		},
 	}
 
@@ -0,0 +1,154 @@
+package internal
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// hashLength is the size of an ObjectHash digest.
+const hashLength = sha256.Size
+
+// ObjectHash returns the ObjectHash of a plain Go object, following
+// https://github.com/benlaurie/objecthash. It is a standalone implementation
+// used to cross-check the ObjectHash of protobuf messages.
+func ObjectHash(o interface{}) ([hashLength]byte, error) {
+	var h [hashLength]byte
+
+	b, err := objectHash(reflect.ValueOf(o))
+	if err != nil {
+		return h, err
+	}
+
+	copy(h[:], b)
+	return h, nil
+}
+
+// CommonJSONHash returns the ObjectHash of a JSON document.
+func CommonJSONHash(j string) ([hashLength]byte, error) {
+	var o interface{}
+	if err := json.Unmarshal([]byte(j), &o); err != nil {
+		return [hashLength]byte{}, err
+	}
+	return ObjectHash(o)
+}
+
+func objectHash(v reflect.Value) ([]byte, error) {
+	if !v.IsValid() {
+		return tagged('n', nil), nil
+	}
+
+	switch v.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		if v.IsNil() {
+			return tagged('n', nil), nil
+		}
+		return objectHash(v.Elem())
+
+	case reflect.Bool:
+		if v.Bool() {
+			return tagged('b', []byte("1")), nil
+		}
+		return tagged('b', []byte("0")), nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return tagged('i', []byte(strconv.FormatInt(v.Int(), 10))), nil
+
+	case reflect.Uint, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return tagged('i', []byte(strconv.FormatUint(v.Uint(), 10))), nil
+
+	case reflect.Float32, reflect.Float64:
+		s, err := normalizeFloat(v.Float())
+		if err != nil {
+			return nil, err
+		}
+		return tagged('f', []byte(s)), nil
+
+	case reflect.String:
+		return tagged('u', []byte(v.String())), nil
+
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return tagged('r', v.Bytes()), nil
+		}
+
+		var buf bytes.Buffer
+		for i := 0; i < v.Len(); i++ {
+			b, err := objectHash(v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			buf.Write(b)
+		}
+		return tagged('l', buf.Bytes()), nil
+
+	case reflect.Map:
+		entries := make([][]byte, 0, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			k, err := objectHash(iter.Key())
+			if err != nil {
+				return nil, err
+			}
+			e, err := objectHash(iter.Value())
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, append(k, e...))
+		}
+
+		sort.Slice(entries, func(i, j int) bool { return bytes.Compare(entries[i], entries[j]) < 0 })
+		return tagged('d', bytes.Join(entries, nil)), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported type for objecthash: %s", v.Type())
+	}
+}
+
+func tagged(tag byte, b []byte) []byte {
+	h := sha256.Sum256(append([]byte{tag}, b...))
+	return h[:]
+}
+
+// normalizeFloat returns the "<sign><exponent>:<mantissa bits>" form of f,
+// where the mantissa is scaled into (0.5, 1].
+func normalizeFloat(f float64) (string, error) {
+	switch {
+	case math.IsNaN(f):
+		return "NaN", nil
+	case math.IsInf(f, 1):
+		return "Infinity", nil
+	case math.IsInf(f, -1):
+		return "-Infinity", nil
+	case f == 0:
+		return "+0:", nil
+	}
+
+	sign := "+"
+	if f < 0 {
+		sign = "-"
+	}
+
+	frac, exp := math.Frexp(math.Abs(f))
+	if frac == 0.5 {
+		frac, exp = 1, exp-1
+	}
+
+	var bits bytes.Buffer
+	for frac != 0 {
+		if frac >= 1 {
+			bits.WriteByte('1')
+			frac--
+		} else {
+			bits.WriteByte('0')
+		}
+		frac *= 2
+	}
+
+	return fmt.Sprintf("%s%d:%s", sign, exp, bits.String()), nil
+}
@@ -0,0 +1,75 @@
+package internal
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Populate sets every field of msg to a value derived from seed, recursing into
+// nested messages up to depth levels. Different seeds select different members
+// of oneofs, and give lists and maps different lengths.
+func Populate(msg protoreflect.Message, seed, depth int) {
+	fields := msg.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() && seed%od.Fields().Len() != fd.Index()-od.Fields().Get(0).Index() {
+			continue
+		}
+		if fd.Message() != nil && !fd.IsMap() && depth == 0 {
+			continue
+		}
+
+		switch {
+		case fd.IsList():
+			list := msg.Mutable(fd).List()
+			for j := 0; j < 1+(seed+i)%3; j++ {
+				list.Append(populatedValue(fd, list.NewElement(), seed+j, depth))
+			}
+
+		case fd.IsMap():
+			if fd.MapValue().Message() != nil && depth == 0 {
+				continue
+			}
+			m := msg.Mutable(fd).Map()
+			for j := 0; j < 1+(seed+i)%3; j++ {
+				k := populatedValue(fd.MapKey(), fd.MapKey().Default(), seed+j, depth).MapKey()
+				m.Set(k, populatedValue(fd.MapValue(), m.NewValue(), seed+j, depth))
+			}
+
+		default:
+			msg.Set(fd, populatedValue(fd, msg.NewField(fd), seed+i, depth))
+		}
+	}
+}
+
+// populatedValue returns a value of the kind of fd derived from seed. Messages
+// are populated in place of v.
+func populatedValue(fd protoreflect.FieldDescriptor, v protoreflect.Value, seed, depth int) protoreflect.Value {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return protoreflect.ValueOfBool(seed%2 == 0)
+	case protoreflect.EnumKind:
+		values := fd.Enum().Values()
+		return protoreflect.ValueOfEnum(values.Get(seed % values.Len()).Number())
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return protoreflect.ValueOfInt32(int32(-seed - 1))
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return protoreflect.ValueOfInt64(int64(seed) << 33)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return protoreflect.ValueOfUint32(uint32(seed + 1))
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return protoreflect.ValueOfUint64(uint64(seed+1) << 40)
+	case protoreflect.FloatKind:
+		return protoreflect.ValueOfFloat32(float32(seed) + 0.5)
+	case protoreflect.DoubleKind:
+		return protoreflect.ValueOfFloat64(float64(seed) - 0.25)
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(fmt.Sprintf("%s-%d", fd.Name(), seed))
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes([]byte{byte(seed), byte(fd.Number())})
+	default:
+		Populate(v.Message(), seed, depth-1)
+		return v
+	}
+}
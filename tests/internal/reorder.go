@@ -0,0 +1,54 @@
+package internal
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/runtime/protoiface"
+)
+
+// ReorderFields wraps msg so that Range yields the populated fields in the order
+// produced by reorder, instead of the order chosen by the protobuf runtime.
+// Singular nested messages are wrapped as well.
+func ReorderFields(msg proto.Message, reorder func([]protoreflect.FieldDescriptor)) proto.Message {
+	return reorderedMessage{Message: msg.ProtoReflect(), reorder: reorder}
+}
+
+type reorderedMessage struct {
+	protoreflect.Message
+	reorder func([]protoreflect.FieldDescriptor)
+}
+
+func (m reorderedMessage) ProtoReflect() protoreflect.Message {
+	return m
+}
+
+func (m reorderedMessage) Interface() protoreflect.ProtoMessage {
+	return m
+}
+
+// ProtoMethods disables the fast paths of the wrapped message, which would
+// bypass the wrapper.
+func (m reorderedMessage) ProtoMethods() *protoiface.Methods {
+	return nil
+}
+
+func (m reorderedMessage) Range(f func(protoreflect.FieldDescriptor, protoreflect.Value) bool) {
+	var fds []protoreflect.FieldDescriptor
+	m.Message.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		fds = append(fds, fd)
+		return true
+	})
+
+	m.reorder(fds)
+
+	for _, fd := range fds {
+		v := m.Message.Get(fd)
+		if fd.Message() != nil && !fd.IsList() && !fd.IsMap() {
+			v = protoreflect.ValueOfMessage(reorderedMessage{Message: v.Message(), reorder: m.reorder})
+		}
+
+		if !f(fd, v) {
+			return
+		}
+	}
+}
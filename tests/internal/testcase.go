@@ -38,65 +38,207 @@ type TestCase struct {
 	// ExpectedHashString is the expected objecthash for all the objects in the
 	// test case.
 	ExpectedHashString string
+
+	// ExpectedHashStringV2 is the expected objecthash for all the objects in the
+	// test case when they are hashed with ph.AlgorithmV2.
+	ExpectedHashStringV2 string
+
+	// ExpectedObjectHashString is the expected SHA-256 objecthash, as returned by
+	// ProtoHasher.ObjectHash, for all the objects in the test case. It was
+	// generated by protohash itself, so it guards against regressions but does
+	// not prove compatibility with other objecthash implementations.
+	ExpectedObjectHashString string
+
+	// UpstreamObjectHashString is the SHA-256 objecthash that the test vectors
+	// of ObjectHash-Proto give for the objects in the test case, which other
+	// objecthash implementations agree on.
+	UpstreamObjectHashString string
+}
+
+// checksObjectHash reports whether the test case has expectations about the
+// SHA-256 objecthash of its objects.
+func (tc TestCase) checksObjectHash() bool {
+	return tc.ExpectedObjectHashString != "" || tc.UpstreamObjectHashString != "" ||
+		tc.EquivalentJSONString != "" || tc.EquivalentObject != nil
+}
+
+// expectedHash returns the expected hash string for the given algorithm.
+func (tc TestCase) expectedHash(alg ph.Algorithm) string {
+	if alg == ph.AlgorithmV2 {
+		return tc.ExpectedHashStringV2
+	}
+	return tc.ExpectedHashString
 }
 
 // Check tests the ObjectHashes for the protos in a TestCase's Protos field.
 //
 // It does the following checks:
-// - The ObjectHashes of the protos (stringified) are equal to the ExpectedHashString.
+// - The ObjectHashes of the protos are all equal.
+// - The ObjectHashes of the protos are equal to the hashes of their wire format.
+// - The digests of the protos, as returned by HashMessageDigest, are all equal.
+// - The ObjectHashes of the protos (stringified) are equal to the ExpectedHashString (ExpectedHashStringV2 for ph.AlgorithmV2).
+// - The SHA-256 ObjectHashes of the protos (stringified) are equal to the ExpectedObjectHashString, if present.
+// - The SHA-256 ObjectHashes of the protos (stringified) are equal to the UpstreamObjectHashString, if present.
 // - The ObjectHashes of the protos are equal to the ObjectHash of the EquivalentJSONString, if present.
 // - The ObjectHashes of the protos are equal to the ObjectHash of the EquivalentObject, if present.
 func (tc TestCase) Check(t *testing.T, hasher *ph.ProtoHasher) {
 	t.Helper()
 
-	for _, message := range tc.Protos {
+	expectedHashString := tc.expectedHash(hasher.Algorithm())
+
+	var firstHashStr, firstDigestStr string
+	for i, message := range tc.Protos {
 		messageHash, err := hasher.HashMessage(message)
 		if err != nil {
 			t.Errorf("Attempting to hash %T{ %[1]v } returned an error: %v", message, err)
 		}
 		messageHashStr := fmt.Sprintf("%x", messageHash)
 
+		// All the protos must hash alike, whether or not an expected hash is given.
+		if i == 0 {
+			firstHashStr = messageHashStr
+		} else if messageHashStr != firstHashStr {
+			t.Errorf("The objecthash for %T{ %[1]v } was expected to be the same as that of %T{ %[2]v }.\n"+
+				"Actual:   %v\nExpected: %v\n", message, tc.Protos[0], messageHashStr, firstHashStr)
+		}
+
+		// All the protos must have the same digest as well.
+		digest, err := hasher.HashMessageDigest(message)
+		if err != nil {
+			t.Errorf("Attempting to digest %T{ %[1]v } returned an error: %v", message, err)
+		}
+		digestStr := fmt.Sprintf("%x", digest)
+		if i == 0 {
+			firstDigestStr = digestStr
+		} else if digestStr != firstDigestStr {
+			t.Errorf("The digest for %T{ %[1]v } was expected to be the same as that of %T{ %[2]v }.\n"+
+				"Actual:   %v\nExpected: %v\n", message, tc.Protos[0], digestStr, firstDigestStr)
+		}
+
+		// Hashing the serialized message must give the same hash.
+		if err == nil {
+			t.Run("Compare to hash of the wire format", func(t *testing.T) {
+				b, err := proto.MarshalOptions{AllowPartial: true}.Marshal(message)
+				if err != nil {
+					t.Fatalf("Attempting to marshal %T{ %[1]v } returned an error: %v", message, err)
+				}
+
+				wireHash, err := hasher.HashWire(message.ProtoReflect().Descriptor(), b)
+				if err != nil {
+					t.Errorf("Attempting to hash the wire format of %T{ %[1]v } returned an error: %v", message, err)
+				}
+				if wireHash != messageHash {
+					t.Errorf("Got the wrong hash for the wire format of %T{ %[1]v }.\n"+
+						"Actual:   %x\nExpected: %x\n", message, wireHash, messageHash)
+				}
+			})
+		}
+
 		// If the test case has an expected hash string, check it.
-		if tc.ExpectedHashString != "" {
+		if expectedHashString != "" {
 			t.Run("Compare to expected hash", func(t *testing.T) {
-				if messageHashStr != tc.ExpectedHashString {
+				if messageHashStr != expectedHashString {
 					t.Errorf("Got the wrong objecthash for %T{ %[1]v }.\n"+
-						"Actual:   %v\nExpected: %v\n", message, messageHashStr, tc.ExpectedHashString)
+						"Actual:   %v\nExpected: %v\n", message, messageHashStr, expectedHashString)
+					t.FailNow()
+				}
+			})
+		}
+
+		// The remaining checks are made against the SHA-256 ObjectHash of the
+		// message, for the test cases that have expectations about it.
+		if !tc.checksObjectHash() {
+			continue
+		}
+		objectHash, err := hasher.ObjectHash(message)
+		if err != nil {
+			t.Errorf("Attempting to objecthash %T{ %[1]v } returned an error: %v", message, err)
+		}
+		objectHashStr := fmt.Sprintf("%x", objectHash)
+
+		// If the test case has an expected objecthash string, check it.
+		if tc.ExpectedObjectHashString != "" {
+			t.Run("Compare to expected objecthash", func(t *testing.T) {
+				if objectHashStr != tc.ExpectedObjectHashString {
+					t.Errorf("Got the wrong objecthash for %T{ %[1]v }.\n"+
+						"Actual:   %v\nExpected: %v\n", message, objectHashStr, tc.ExpectedObjectHashString)
+					t.FailNow()
+				}
+			})
+		}
+
+		// If the test case has an upstream objecthash string, check it.
+		if tc.UpstreamObjectHashString != "" {
+			t.Run("Compare to upstream objecthash", func(t *testing.T) {
+				if objectHashStr != tc.UpstreamObjectHashString {
+					t.Errorf("Got the wrong objecthash for %T{ %[1]v }, which does not match ObjectHash-Proto.\n"+
+						"Actual:   %v\nExpected: %v\n", message, objectHashStr, tc.UpstreamObjectHashString)
 					t.FailNow()
 				}
 			})
 		}
 
 		// If the test case has an equivalent JSON String, check it.
-		// if tc.EquivalentJSONString != "" {
-		// 	t.Run("Compare to objecthash of the equivalent JSON", func(t *testing.T) {
-		// 		commonJSONHash, err := objecthash.CommonJSONHash(tc.EquivalentJSONString)
-		// 		if err != nil {
-		// 			t.Errorf("Attempting to hash %+v returned an error: %v", tc.EquivalentJSONString, err)
-		// 		}
-		// 		commonJSONHashStr := fmt.Sprintf("%x", commonJSONHash)
-
-		// 		if messageHashStr != commonJSONHashStr {
-		// 			t.Errorf("The objecthash for %T{ %[1]v } was expected to be the same as that of %+v.\n"+
-		// 				"Actual:   %v\nExpected: %v\n", message, tc.EquivalentJSONString, messageHashStr, commonJSONHashStr)
-		// 		}
-		// 	})
-		// }
+		if tc.EquivalentJSONString != "" {
+			t.Run("Compare to objecthash of the equivalent JSON", func(t *testing.T) {
+				commonJSONHash, err := CommonJSONHash(tc.EquivalentJSONString)
+				if err != nil {
+					t.Errorf("Attempting to hash %+v returned an error: %v", tc.EquivalentJSONString, err)
+				}
+				commonJSONHashStr := fmt.Sprintf("%x", commonJSONHash)
+
+				if objectHashStr != commonJSONHashStr {
+					t.Errorf("The objecthash for %T{ %[1]v } was expected to be the same as that of %+v.\n"+
+						"Actual:   %v\nExpected: %v\n", message, tc.EquivalentJSONString, objectHashStr, commonJSONHashStr)
+				}
+			})
+		}
 
 		// If the test case has an equivalent object, check it.
-		// if tc.EquivalentObject != nil {
-		// 	t.Run("Compare to objecthash of the equivalent Go object", func(t *testing.T) {
-		// 		EquivalentObjectHash, err := objecthash.ObjectHash(tc.EquivalentObject)
-		// 		if err != nil {
-		// 			t.Errorf("Attempting to hash %+v returned an error: %v", tc.EquivalentObject, err)
-		// 		}
-		// 		EquivalentObjectHashStr := fmt.Sprintf("%x", EquivalentObjectHash)
-
-		// 		if messageHashStr != EquivalentObjectHashStr {
-		// 			t.Errorf("The objecthash for %T{ %[1]v } was expected to be the same as that of %+v.\n"+
-		// 				"Actual:   %v\nExpected: %v\n", message, tc.EquivalentObject, messageHashStr, EquivalentObjectHashStr)
-		// 		}
-		// 	})
-		// }
+		if tc.EquivalentObject != nil {
+			t.Run("Compare to objecthash of the equivalent Go object", func(t *testing.T) {
+				EquivalentObjectHash, err := ObjectHash(tc.EquivalentObject)
+				if err != nil {
+					t.Errorf("Attempting to hash %+v returned an error: %v", tc.EquivalentObject, err)
+				}
+				EquivalentObjectHashStr := fmt.Sprintf("%x", EquivalentObjectHash)
+
+				if objectHashStr != EquivalentObjectHashStr {
+					t.Errorf("The objecthash for %T{ %[1]v } was expected to be the same as that of %+v.\n"+
+						"Actual:   %v\nExpected: %v\n", message, tc.EquivalentObject, objectHashStr, EquivalentObjectHashStr)
+				}
+			})
+		}
+	}
+}
+
+// CheckDistinct tests that no two of the given protos share an objecthash, nor
+// a digest.
+func CheckDistinct(t *testing.T, hasher *ph.ProtoHasher, protos ...proto.Message) {
+	t.Helper()
+
+	seen := make(map[uint64]proto.Message, len(protos))
+	seenDigests := make(map[string]proto.Message, len(protos))
+	for _, message := range protos {
+		digest, err := hasher.HashMessageDigest(message)
+		if err != nil {
+			t.Errorf("Attempting to digest %T{ %[1]v } returned an error: %v", message, err)
+		} else {
+			if other, ok := seenDigests[string(digest)]; ok {
+				t.Errorf("%T{ %[1]v } and %T{ %[2]v } have the same digest: %x", other, message, digest)
+			}
+			seenDigests[string(digest)] = message
+		}
+
+		messageHash, err := hasher.HashMessage(message)
+		if err != nil {
+			t.Errorf("Attempting to hash %T{ %[1]v } returned an error: %v", message, err)
+			continue
+		}
+
+		if other, ok := seen[messageHash]; ok {
+			t.Errorf("%T{ %[1]v } and %T{ %[2]v } have the same objecthash: %x", other, message, messageHash)
+		}
+		seen[messageHash] = message
 	}
 }
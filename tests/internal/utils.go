@@ -14,32 +14,31 @@
 
 package internal
 
-// import (
-// 	"testing"
+import (
+	"testing"
 
-// 	pb2_latest "github.com/deepmind/objecthash-proto/test_protos/generated/latest/proto2"
+	pb2 "github.com/aserto-dev/go-protohash/tests/api/proto2/v1"
+	"google.golang.org/protobuf/proto"
+)
 
-// 	"github.com/golang/protobuf/proto"
-// )
-
-// // ForgetAllFields takes a proto message and turns all its fields into unknown
-// // fields.
-// //
-// // It does this by marshalling the proto message and then parsing it as an
-// // empty message.
-// func ForgetAllFields(t *testing.T, originalMessage proto.Message) proto.Message {
-// 	t.Helper()
-
-// 	emptyMessage := &pb2_latest.Empty{}
-
-// 	binaryMessage, err := proto.Marshal(originalMessage)
-// 	if err != nil {
-// 		t.Error(err)
-// 	}
-
-// 	err = proto.Unmarshal(binaryMessage, emptyMessage)
-// 	if err != nil {
-// 		t.Error(err)
-// 	}
-// 	return emptyMessage
-// }
+// ForgetAllFields takes a proto message and turns all its fields into unknown
+// fields.
+//
+// It does this by marshalling the proto message and then parsing it as an
+// empty message.
+func ForgetAllFields(t *testing.T, originalMessage proto.Message) proto.Message {
+	t.Helper()
+
+	emptyMessage := &pb2.Empty{}
+
+	binaryMessage, err := proto.Marshal(originalMessage)
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = proto.Unmarshal(binaryMessage, emptyMessage)
+	if err != nil {
+		t.Error(err)
+	}
+	return emptyMessage
+}
@@ -35,8 +35,10 @@ func TestMaps(t *testing.T, hasher *ph.ProtoHasher) {
 				&api.BoolMaps{BoolToString: map[bool]string{true: "NOT FALSE", false: "NOT TRUE"}},
 			},
 			// No equivalent JSON object because JSON map keys must be strings.
-			EquivalentObject:   map[string]map[bool]string{"bool_to_string": {true: "NOT FALSE", false: "NOT TRUE"}},
-			ExpectedHashString: "6f6b5869cdd9333",
+			EquivalentObject:         map[string]map[bool]string{"bool_to_string": {true: "NOT FALSE", false: "NOT TRUE"}},
+			ExpectedHashString:       "6f6b5869cdd9333",
+			ExpectedObjectHashString: "d89d053bf7b37b4784832c72445661db99538fe1d490988575409a9040084f18",
+			ExpectedHashStringV2:     "b91d45d5d960d02f",
 		},
 
 		////////////////////
@@ -47,8 +49,10 @@ func TestMaps(t *testing.T, hasher *ph.ProtoHasher) {
 				&api.IntMaps{IntToString: map[int64]string{0: "ZERO"}},
 			},
 			// No equivalent JSON object because JSON map keys must be strings.
-			EquivalentObject:   map[string]map[int64]string{"int_to_string": {0: "ZERO"}},
-			ExpectedHashString: "cb97c968692e8b24",
+			EquivalentObject:         map[string]map[int64]string{"int_to_string": {0: "ZERO"}},
+			ExpectedHashString:       "cb97c968692e8b24",
+			ExpectedObjectHashString: "53892192fb69cbd93ceb0552ca571b8505887f25d6f12822025341f16983a6af",
+			ExpectedHashStringV2:     "27660c69142f7730",
 		},
 
 		///////////////////
@@ -58,9 +62,11 @@ func TestMaps(t *testing.T, hasher *ph.ProtoHasher) {
 			Protos: []proto.Message{
 				&api.StringMaps{StringToString: map[string]string{"foo": "bar"}},
 			},
-			EquivalentJSONString: "{\"string_to_string\": {\"foo\": \"bar\"}}",
-			EquivalentObject:     map[string]map[string]string{"string_to_string": {"foo": "bar"}},
-			ExpectedHashString:   "c5f3d4ac79aa224b",
+			EquivalentJSONString:     "{\"string_to_string\": {\"foo\": \"bar\"}}",
+			EquivalentObject:         map[string]map[string]string{"string_to_string": {"foo": "bar"}},
+			ExpectedHashString:       "c5f3d4ac79aa224b",
+			ExpectedObjectHashString: "cadfe560995647c63c20234a6409d2b1b8cf8dcf7d8e420ca33f23ff9ca9abfa",
+			ExpectedHashStringV2:     "789abcf85e8a0a3d",
 		},
 
 		{
@@ -68,9 +74,11 @@ func TestMaps(t *testing.T, hasher *ph.ProtoHasher) {
 				&api.StringMaps{StringToString: map[string]string{
 					"": "你好", "你好": "\u03d3", "\u03d3": "\u03d2\u0301"}},
 			},
-			EquivalentJSONString: "{\"string_to_string\": {\"\": \"你好\", \"你好\": \"\u03d3\", \"\u03d3\": \"\u03d2\u0301\"}}",
-			EquivalentObject:     map[string]map[string]string{"string_to_string": {"": "你好", "你好": "\u03d3", "\u03d3": "\u03d2\u0301"}},
-			ExpectedHashString:   "fd2644e21e9d8a32",
+			EquivalentJSONString:     "{\"string_to_string\": {\"\": \"你好\", \"你好\": \"\u03d3\", \"\u03d3\": \"\u03d2\u0301\"}}",
+			EquivalentObject:         map[string]map[string]string{"string_to_string": {"": "你好", "你好": "\u03d3", "\u03d3": "\u03d2\u0301"}},
+			ExpectedHashString:       "fd2644e21e9d8a32",
+			ExpectedObjectHashString: "be8b5ae6d5986cde37ab8b395c66045fbb69a8b3b534fa34df7c19a640f4cd66",
+			ExpectedHashStringV2:     "6ef52a299c409c76",
 		},
 
 		//////////////////////////////
@@ -80,9 +88,11 @@ func TestMaps(t *testing.T, hasher *ph.ProtoHasher) {
 			Protos: []proto.Message{
 				&api.StringMaps{StringToSimple: map[string]*api.Simple{"foo": {}}},
 			},
-			EquivalentJSONString: "{\"string_to_simple\": {\"foo\": {}}}",
-			EquivalentObject:     map[string]map[string]map[string]string{"string_to_simple": {"foo": {}}},
-			ExpectedHashString:   "c76d7fcd4b54fd92",
+			EquivalentJSONString:     "{\"string_to_simple\": {\"foo\": {}}}",
+			EquivalentObject:         map[string]map[string]map[string]string{"string_to_simple": {"foo": {}}},
+			ExpectedHashString:       "c76d7fcd4b54fd92",
+			ExpectedObjectHashString: "58057927bb1a123452a2d75071b55b08e426490ca42c3dd14e3be59183ac4751",
+			ExpectedHashStringV2:     "23749b2e02e8e79d",
 		},
 	}
 
@@ -36,9 +36,11 @@ func TestOneOfFields(t *testing.T, hasher *ph.ProtoHasher) {
 
 				&api.Empty{},
 			},
-			EquivalentJSONString: "{}",
-			EquivalentObject:     map[int64]string{},
-			ExpectedHashString:   "0",
+			EquivalentJSONString:     "{}",
+			EquivalentObject:         map[int64]string{},
+			ExpectedHashString:       "0",
+			ExpectedObjectHashString: "18ac3e7343f016890c510e93f935261169d9e3f565436429830faf0934f4f8e4",
+			ExpectedHashStringV2:     "0",
 		},
 
 		/////////////////////////////////////////////
@@ -51,8 +53,10 @@ func TestOneOfFields(t *testing.T, hasher *ph.ProtoHasher) {
 				&api.Singleton{Singleton: &api.Singleton_TheBool{TheBool: false}},
 			},
 			// No equivalent JSON because JSON maps have to have strings as keys.
-			EquivalentObject:   map[int64]bool{1: false},
-			ExpectedHashString: "fc42f4d44454522d",
+			EquivalentObject:         map[int64]bool{1: false},
+			ExpectedHashString:       "fc42f4d44454522d",
+			ExpectedObjectHashString: "8a956cfa8e9b45b738cb8dc8a3dc7126dab3cbd2c07c80fa1ec312a1a31ed709",
+			ExpectedHashStringV2:     "5459e8a3aeea9d95",
 		},
 
 		{
@@ -62,8 +66,10 @@ func TestOneOfFields(t *testing.T, hasher *ph.ProtoHasher) {
 				&api.Singleton{Singleton: &api.Singleton_TheString{TheString: ""}},
 			},
 			// No equivalent JSON because JSON maps have to have strings as keys.
-			EquivalentObject:   map[int64]string{25: ""},
-			ExpectedHashString: "5fd4b748b2f5442c",
+			EquivalentObject:         map[int64]string{25: ""},
+			ExpectedHashString:       "5fd4b748b2f5442c",
+			ExpectedObjectHashString: "79cff9d2d0ee6c6071c82b58d1a2fcf056b58c4501606862489e5731644c755a",
+			ExpectedHashStringV2:     "987e74385bd705f3",
 		},
 
 		{
@@ -73,8 +79,10 @@ func TestOneOfFields(t *testing.T, hasher *ph.ProtoHasher) {
 				&api.Singleton{Singleton: &api.Singleton_TheInt32{TheInt32: 0}},
 			},
 			// No equivalent JSON because JSON maps have to have strings as keys.
-			EquivalentObject:   map[int64]int32{13: 0},
-			ExpectedHashString: "cb720bf58a2c29ec",
+			EquivalentObject:         map[int64]int32{13: 0},
+			ExpectedHashString:       "cb720bf58a2c29ec",
+			ExpectedObjectHashString: "bafd42680c987c47a76f72e08ed975877162efdb550d2c564c758dc7d988468f",
+			ExpectedHashStringV2:     "1b447ef2cbb7fe57",
 		},
 
 		////////////////////////////////////////////////
@@ -103,8 +111,10 @@ func TestOneOfFields(t *testing.T, hasher *ph.ProtoHasher) {
 				&api.Singleton{Singleton: &api.Singleton_TheString{TheString: "TEST!"}},
 			},
 			// No equivalent JSON because JSON maps have to have strings as keys.
-			EquivalentObject:   map[int64]string{25: "TEST!"},
-			ExpectedHashString: "6e388481a9f4259e",
+			EquivalentObject:         map[int64]string{25: "TEST!"},
+			ExpectedHashString:       "6e388481a9f4259e",
+			ExpectedObjectHashString: "336cdbca99fd46157bc47bcc456f0ac7f1ef3be7a79acf3535f671434b53944f",
+			ExpectedHashStringV2:     "13d39215cc61d563",
 		},
 
 		{
@@ -114,8 +124,10 @@ func TestOneOfFields(t *testing.T, hasher *ph.ProtoHasher) {
 				&api.Singleton{Singleton: &api.Singleton_TheInt32{TheInt32: 99}},
 			},
 			// No equivalent JSON because JSON maps have to have strings as keys.
-			EquivalentObject:   map[int64]int32{13: 99},
-			ExpectedHashString: "7ad488431568d7ef",
+			EquivalentObject:         map[int64]int32{13: 99},
+			ExpectedHashString:       "7ad488431568d7ef",
+			ExpectedObjectHashString: "65517521bc278528d25caf1643da0f094fd88dad50205c9743e3c984a7c53b7d",
+			ExpectedHashStringV2:     "a0ba56be60ec506d",
 		},
 
 		///////////////////////////
@@ -130,7 +142,9 @@ func TestOneOfFields(t *testing.T, hasher *ph.ProtoHasher) {
 			// No equivalent JSON because JSON maps have to have strings as keys.
 			EquivalentObject: map[int64]map[int64]int64{35: {}},
 			// EquivalentObject:   map[int64]map[int64]map[int64]int64{35: {35: {}}},
-			ExpectedHashString: "88201fb960ff6465",
+			ExpectedHashString:       "88201fb960ff6465",
+			ExpectedObjectHashString: "4967c72525c764229f9fbf1294764c9aedc0d4f9f4c52e04a19c7f35ca65f517",
+			ExpectedHashStringV2:     "dbd76ea533bc66bc",
 		},
 
 		{
@@ -140,8 +154,10 @@ func TestOneOfFields(t *testing.T, hasher *ph.ProtoHasher) {
 				&api.Singleton{Singleton: &api.Singleton_TheSingleton{TheSingleton: &api.Singleton{Singleton: &api.Singleton_TheSingleton{TheSingleton: &api.Singleton{}}}}},
 			},
 			// No equivalent JSON because JSON maps have to have strings as keys.
-			EquivalentObject:   map[int64]map[int64]map[int64]int64{35: {35: {}}},
-			ExpectedHashString: "661a6df2c7688a1b",
+			EquivalentObject:         map[int64]map[int64]map[int64]int64{35: {35: {}}},
+			ExpectedHashString:       "661a6df2c7688a1b",
+			ExpectedObjectHashString: "8ea95bbda0f42073a61f46f9f375f48d5a7cb034fce56b44f958470fda5236d0",
+			ExpectedHashStringV2:     "240bf491d1f34c43",
 		},
 	}
 
@@ -158,10 +174,12 @@ func checkAsSingletonOnTheWire(t *testing.T, tc ti.TestCase, hasher *ph.ProtoHas
 	t.Helper()
 
 	testCaseAfterAWireTransfer := ti.TestCase{
-		Protos:               tc.Protos,
-		EquivalentJSONString: tc.EquivalentJSONString,
-		EquivalentObject:     tc.EquivalentObject,
-		ExpectedHashString:   tc.ExpectedHashString,
+		Protos:                   tc.Protos,
+		EquivalentJSONString:     tc.EquivalentJSONString,
+		EquivalentObject:         tc.EquivalentObject,
+		ExpectedHashString:       tc.ExpectedHashString,
+		ExpectedHashStringV2:     tc.ExpectedHashStringV2,
+		ExpectedObjectHashString: tc.ExpectedObjectHashString,
 	}
 
 	for i, pb := range tc.Protos {
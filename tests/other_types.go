@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	ph "github.com/aserto-dev/go-protohash"
+	pb2 "github.com/aserto-dev/go-protohash/tests/api/proto2/v1"
 	"github.com/aserto-dev/go-protohash/tests/api/v1"
 	ti "github.com/aserto-dev/go-protohash/tests/internal"
 	"google.golang.org/protobuf/proto"
@@ -46,20 +47,24 @@ func TestOtherTypes(t *testing.T, hasher *ph.ProtoHasher) {
 			Protos: []proto.Message{
 				&api.Simple{BoolField: true},
 			},
-			EquivalentJSONString: "{\"bool_field\": true}",
-			EquivalentObject:     map[string]bool{"bool_field": true},
-			ExpectedHashString:   "f7a206297de86dbe",
+			EquivalentJSONString:     "{\"bool_field\": true}",
+			EquivalentObject:         map[string]bool{"bool_field": true},
+			ExpectedHashString:       "f7a206297de86dbe",
+			ExpectedObjectHashString: "7b2ac6048e6c8797205505ea486539a5589583be43154da88785a5121e2d6899",
+			ExpectedHashStringV2:     "245f682759c3abc",
 		},
 
-		// {
-		// 	Protos: []proto.Message{
-		// 		&pb2_latest.Simple{BoolField: proto.Bool(false)},
-		// 		// proto3 scalar fields set to their default value are considered empty.
-		// 	},
-		// 	EquivalentJSONString: "{\"bool_field\": false}",
-		// 	EquivalentObject:     map[string]bool{"bool_field": false},
-		// 	ExpectedHashString:   "1ab5ecdbe4176473024f7efd080593b740d22d076d06ea6edd8762992b484a12",
-		// },
+		{
+			Protos: []proto.Message{
+				&pb2.Simple{BoolField: proto.Bool(false)},
+				// proto3 scalar fields set to their default value are considered empty.
+			},
+			EquivalentJSONString:     "{\"bool_field\": false}",
+			EquivalentObject:         map[string]bool{"bool_field": false},
+			ExpectedHashString:       "fc42f4d44454522d",
+			ExpectedHashStringV2:     "5459e8a3aeea9d95",
+			UpstreamObjectHashString: "1ab5ecdbe4176473024f7efd080593b740d22d076d06ea6edd8762992b484a12",
+		},
 
 		///////////////////
 		// Bytes fields. //
@@ -69,8 +74,10 @@ func TestOtherTypes(t *testing.T, hasher *ph.ProtoHasher) {
 				&api.Simple{BytesField: []byte{0, 0, 0}},
 			},
 			// No equivalent JSON: JSON does not have a "bytes" type.
-			EquivalentObject:   map[string][]byte{"bytes_field": []byte("\000\000\000")},
-			ExpectedHashString: "cb59b0693719a410",
+			EquivalentObject:         map[string][]byte{"bytes_field": []byte("\000\000\000")},
+			ExpectedHashString:       "cb59b0693719a410",
+			ExpectedObjectHashString: "fdd59e1f3120117943124cb9c39da79ac47ea631343ff9154dffb0e64550789c",
+			ExpectedHashStringV2:     "ef42a781ea704743",
 		},
 	}
 
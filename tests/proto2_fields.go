@@ -0,0 +1,237 @@
+package tests
+
+import (
+	"testing"
+
+	ph "github.com/aserto-dev/go-protohash"
+	pb2 "github.com/aserto-dev/go-protohash/tests/api/proto2/v1"
+	"github.com/aserto-dev/go-protohash/tests/api/v1"
+	ti "github.com/aserto-dev/go-protohash/tests/internal"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestProto2DefaultFieldValues performs tests on how proto2 explicit presence
+// and default values are handled.
+func TestProto2DefaultFieldValues(t *testing.T, hasher *ph.ProtoHasher) {
+
+	testCases := []ti.TestCase{
+		////////////////////
+		//  Unset fields. //
+		////////////////////
+
+		// Unset proto2 fields are ignored, including the ones with a default value.
+		{
+			Protos: []proto.Message{
+				&pb2.Empty{},
+				&pb2.Simple{},
+				&pb2.Defaults{},
+			},
+			EquivalentJSONString:     "{}",
+			EquivalentObject:         map[string]interface{}{},
+			ExpectedHashString:       "0",
+			ExpectedHashStringV2:     "0",
+			ExpectedObjectHashString: "18ac3e7343f016890c510e93f935261169d9e3f565436429830faf0934f4f8e4",
+		},
+
+		////////////////////////////////////
+		//  Fields explicitly set to zero. //
+		////////////////////////////////////
+
+		// Unlike proto3 scalars, proto2 fields explicitly set to their zero value
+		// are hashed.
+		{
+			Protos: []proto.Message{
+				&pb2.Simple{BoolField: proto.Bool(false)},
+			},
+			EquivalentJSONString:     "{\"bool_field\": false}",
+			EquivalentObject:         map[string]bool{"bool_field": false},
+			ExpectedHashString:       "fc42f4d44454522d",
+			ExpectedHashStringV2:     "5459e8a3aeea9d95",
+			UpstreamObjectHashString: "1ab5ecdbe4176473024f7efd080593b740d22d076d06ea6edd8762992b484a12",
+		},
+
+		{
+			Protos: []proto.Message{
+				&pb2.Simple{Int32Field: proto.Int32(0)},
+			},
+			EquivalentObject:         map[string]int32{"int32_field": 0},
+			ExpectedHashString:       "cb720bf58a2c29ec",
+			ExpectedHashStringV2:     "1b447ef2cbb7fe57",
+			ExpectedObjectHashString: "c2a3622daa6d14b19151e20f2c837b840cc1ca081ff20c83a6b746d9a146fee6",
+		},
+
+		{
+			Protos: []proto.Message{
+				&pb2.Simple{StringField: proto.String("")},
+			},
+			EquivalentJSONString:     "{\"string_field\": \"\"}",
+			EquivalentObject:         map[string]string{"string_field": ""},
+			ExpectedHashString:       "5fd4b748b2f5442c",
+			ExpectedHashStringV2:     "987e74385bd705f3",
+			ExpectedObjectHashString: "2d60c2941830ef4bb14424e47c6cd010f2b95e5e34291f429998288a60ac8c22",
+		},
+
+		//////////////////////////////////////////////
+		//  Fields explicitly set to their default. //
+		//////////////////////////////////////////////
+
+		// A field explicitly set to its default value is hashed like any other
+		// value, the [default = ...] annotation does not matter.
+		{
+			Protos: []proto.Message{
+				&pb2.Defaults{Int32Field: proto.Int32(-7)},
+				&pb2.Simple{Int32Field: proto.Int32(-7)},
+			},
+			EquivalentObject:         map[string]int32{"int32_field": -7},
+			ExpectedHashString:       "cf7c3f99c680713f",
+			ExpectedHashStringV2:     "f79093cbca81d561",
+			ExpectedObjectHashString: "a6f1c33fa2a43a11d2700e5a778e9279240e2d37875c89aca4cdebd07707def9",
+		},
+
+		{
+			Protos: []proto.Message{
+				&pb2.Defaults{BoolField: proto.Bool(true), StringField: proto.String("default")},
+				&pb2.Simple{BoolField: proto.Bool(true), StringField: proto.String("default")},
+			},
+			EquivalentJSONString:     "{\"bool_field\": true, \"string_field\": \"default\"}",
+			EquivalentObject:         map[string]interface{}{"bool_field": true, "string_field": "default"},
+			ExpectedHashString:       "4d83dfdc54e5256d",
+			ExpectedHashStringV2:     "ac928b7a62396c95",
+			ExpectedObjectHashString: "cc9d078fc3dc3b909bc105897b047daa511705476a5fe16e100b3d207abd19d3",
+		},
+
+		{
+			Protos: []proto.Message{
+				&pb2.Defaults{ColorField: pb2.Color_COLOR_GREEN.Enum()},
+				&pb2.Simple{ColorField: pb2.Color_COLOR_GREEN.Enum()},
+			},
+			EquivalentObject:         map[string]int32{"color_field": 2},
+			ExpectedHashString:       "e632e8055abc01d5",
+			ExpectedHashStringV2:     "7357e39337576f83",
+			ExpectedObjectHashString: "cea0c773479db33e80f598165cfdfbba57b6e43d63ed705ca1ecdd1f5a512cfe",
+		},
+
+		/////////////////////////////////////////
+		//  Proto2 and proto3 non-zero values. //
+		/////////////////////////////////////////
+		{
+			Protos: []proto.Message{
+				&pb2.Simple{StringField: proto.String("TEST!"), SimpleField: &pb2.Simple{Uint64Field: proto.Uint64(1)}},
+				&api.Simple{StringField: "TEST!", SimpleField: &api.Simple{Uint64Field: 1}},
+			},
+			EquivalentObject:         map[string]interface{}{"string_field": "TEST!", "simple_field": map[string]uint64{"uint64_field": 1}},
+			ExpectedHashString:       "bef7d0e7f385a29a",
+			ExpectedHashStringV2:     "f14051655e45b9a7",
+			ExpectedObjectHashString: "678bd4367fd70596a7cdcdb750bbe63fa44c2644a787b4eb91ed2dcbe939a556",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc.Check(t, hasher)
+	}
+
+	distinctCases := [][]proto.Message{
+		{
+			&pb2.Simple{},
+			&pb2.Simple{BoolField: proto.Bool(false)},
+			&pb2.Simple{StringField: proto.String("")},
+		},
+
+		{
+			&pb2.Defaults{},
+			&pb2.Defaults{Int32Field: proto.Int32(-7)},
+			&pb2.Defaults{ColorField: pb2.Color_COLOR_GREEN.Enum()},
+		},
+	}
+
+	for _, protos := range distinctCases {
+		ti.CheckDistinct(t, hasher, protos...)
+	}
+}
+
+// TestProto2RequiredFields performs tests on how proto2 required fields are handled.
+func TestProto2RequiredFields(t *testing.T, hasher *ph.ProtoHasher) {
+
+	testCases := []ti.TestCase{
+		{
+			Protos: []proto.Message{
+				&pb2.Required{Id: proto.Int32(1)},
+			},
+			EquivalentObject:         map[string]int32{"id": 1},
+			ExpectedHashString:       "2b37a45929a3305d",
+			ExpectedHashStringV2:     "b97250a099a6f28a",
+			ExpectedObjectHashString: "3dab54b978589e75a2357611aca8033121c99abda819822458a359d87de4d5ea",
+		},
+
+		{
+			Protos: []proto.Message{
+				&pb2.Required{Id: proto.Int32(0), Child: &pb2.Required{Id: proto.Int32(1), Name: proto.String("child")}},
+			},
+			EquivalentObject:         map[string]interface{}{"id": 0, "child": map[string]interface{}{"id": 1, "name": "child"}},
+			ExpectedHashString:       "f73256e35ffcb54a",
+			ExpectedHashStringV2:     "54a1280ca37761ee",
+			ExpectedObjectHashString: "e6b89e3c0f7efcf355135c0deea82189b191a4efd444d87004f317f994bd8aa6",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc.Check(t, hasher)
+	}
+
+	// Messages with missing required fields cannot be hashed.
+	for _, message := range []proto.Message{
+		&pb2.Required{},
+		&pb2.Required{Name: proto.String("no id")},
+		&pb2.Required{Id: proto.Int32(1), Child: &pb2.Required{}},
+	} {
+		if _, err := hasher.HashMessage(message); err == nil {
+			t.Errorf("Hashing %T{ %[1]v } with missing required fields did not return an error", message)
+		}
+		if _, err := hasher.ObjectHash(message); err == nil {
+			t.Errorf("Objecthashing %T{ %[1]v } with missing required fields did not return an error", message)
+		}
+	}
+}
+
+// TestProto2Groups performs tests on how proto2 groups are handled.
+func TestProto2Groups(t *testing.T, hasher *ph.ProtoHasher) {
+
+	testCases := []ti.TestCase{
+		// Groups are hashed like the equivalent nested messages.
+		{
+			Protos: []proto.Message{
+				&pb2.Grouped{Data: &pb2.Grouped_Data{}},
+				&pb2.Ungrouped{Data: &pb2.Ungrouped_Data{}},
+			},
+			EquivalentJSONString:     "{\"data\": {}}",
+			EquivalentObject:         map[string]map[string]interface{}{"data": {}},
+			ExpectedHashString:       "88201fb960ff6465",
+			ExpectedHashStringV2:     "93390f2a997e7d0f",
+			ExpectedObjectHashString: "7ea74880ea5f77274b6c1f1c21217f7686162c6b06c7ed28b7f29f4a2ea849ec",
+		},
+
+		{
+			Protos: []proto.Message{
+				&pb2.Grouped{
+					Data: &pb2.Grouped_Data{Value: proto.Int32(1), Name: proto.String("one")},
+					Item: []*pb2.Grouped_Item{{Value: proto.Int32(5)}, {Value: proto.Int32(6)}},
+				},
+				&pb2.Ungrouped{
+					Data: &pb2.Ungrouped_Data{Value: proto.Int32(1), Name: proto.String("one")},
+					Item: []*pb2.Ungrouped_Item{{Value: proto.Int32(5)}, {Value: proto.Int32(6)}},
+				},
+			},
+			EquivalentObject: map[string]interface{}{
+				"data": map[string]interface{}{"value": 1, "name": "one"},
+				"item": []map[string]int32{{"value": 5}, {"value": 6}},
+			},
+			ExpectedHashString:       "38c723cbcd6768d4",
+			ExpectedHashStringV2:     "bd6454d7a91f30af",
+			ExpectedObjectHashString: "288486f9611f2f2b3e913e306c7f8496d0728f2e1d7f1a86610614887d7332ff",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc.Check(t, hasher)
+	}
+}
@@ -56,9 +56,11 @@ func TestRepeatedFields(t *testing.T, hasher *ph.ProtoHasher) {
 					SingletonField:  []*api.Singleton{},
 				},
 			},
-			EquivalentJSONString: "{}",
-			EquivalentObject:     map[string]interface{}{},
-			ExpectedHashString:   "0",
+			EquivalentJSONString:     "{}",
+			EquivalentObject:         map[string]interface{}{},
+			ExpectedHashString:       "0",
+			ExpectedObjectHashString: "18ac3e7343f016890c510e93f935261169d9e3f565436429830faf0934f4f8e4",
+			ExpectedHashStringV2:     "0",
 		},
 
 		//////////////////////////
@@ -68,27 +70,33 @@ func TestRepeatedFields(t *testing.T, hasher *ph.ProtoHasher) {
 			Protos: []proto.Message{
 				&api.Repetitive{StringField: []string{""}},
 			},
-			EquivalentJSONString: "{\"string_field\": [\"\"]}",
-			EquivalentObject:     map[string][]string{"string_field": {""}},
-			ExpectedHashString:   "bab48eecfa8cd51a",
+			EquivalentJSONString:     "{\"string_field\": [\"\"]}",
+			EquivalentObject:         map[string][]string{"string_field": {""}},
+			ExpectedHashString:       "bab48eecfa8cd51a",
+			ExpectedObjectHashString: "63e64f0ed286e0d8f30735e6646ea9ef48174c23ba09a05288b4233c6e6a9419",
+			ExpectedHashStringV2:     "ad243438bbdbfd32",
 		},
 
 		{
 			Protos: []proto.Message{
 				&api.Repetitive{StringField: []string{"foo"}},
 			},
-			EquivalentJSONString: "{\"string_field\": [\"foo\"]}",
-			EquivalentObject:     map[string][]string{"string_field": {"foo"}},
-			ExpectedHashString:   "e781d93648f4e29b",
+			EquivalentJSONString:     "{\"string_field\": [\"foo\"]}",
+			EquivalentObject:         map[string][]string{"string_field": {"foo"}},
+			ExpectedHashString:       "e781d93648f4e29b",
+			ExpectedObjectHashString: "54c0b7c6e7c9ff0bb6076a2caeccbc96fad77f49b17b7ec9bc17dfe98a7b343e",
+			ExpectedHashStringV2:     "51962651429a91ea",
 		},
 
 		{
 			Protos: []proto.Message{
 				&api.Repetitive{StringField: []string{"foo", "bar"}},
 			},
-			EquivalentJSONString: "{\"string_field\": [\"foo\", \"bar\"]}",
-			EquivalentObject:     map[string][]string{"string_field": {"foo", "bar"}},
-			ExpectedHashString:   "5e398a810a1e8af7",
+			EquivalentJSONString:     "{\"string_field\": [\"foo\", \"bar\"]}",
+			EquivalentObject:         map[string][]string{"string_field": {"foo", "bar"}},
+			ExpectedHashString:       "5e398a810a1e8af7",
+			ExpectedObjectHashString: "a971a061d199ddf37a365d617f9cd4530efb15e933e0dbaf6602b2908b792056",
+			ExpectedHashStringV2:     "89732a8b4af414d",
 		},
 
 		///////////////////////
@@ -101,24 +109,30 @@ func TestRepeatedFields(t *testing.T, hasher *ph.ProtoHasher) {
 			Protos: []proto.Message{
 				&api.Repetitive{Int64Field: []int64{0}},
 			},
-			EquivalentObject:   map[string][]int64{"int64_field": {0}},
-			ExpectedHashString: "88abed3eda001f87",
+			EquivalentObject:         map[string][]int64{"int64_field": {0}},
+			ExpectedHashString:       "88abed3eda001f87",
+			ExpectedObjectHashString: "b7e7afd1c1c7beeec4dcc0ced0ec4af2c850add686a12987e8f0b6fcb603733a",
+			ExpectedHashStringV2:     "daabc55454980525",
 		},
 
 		{
 			Protos: []proto.Message{
 				&api.Repetitive{Int64Field: []int64{-2, -1, 0, 1, 2}},
 			},
-			EquivalentObject:   map[string][]int64{"int64_field": {-2, -1, 0, 1, 2}},
-			ExpectedHashString: "8e40d97221a0dba3",
+			EquivalentObject:         map[string][]int64{"int64_field": {-2, -1, 0, 1, 2}},
+			ExpectedHashString:       "8e40d97221a0dba3",
+			ExpectedObjectHashString: "44e78ff73bdf5d0da5141e110b22bab240483ba17c40f83553a0e6bbfa671e22",
+			ExpectedHashStringV2:     "e4c4d45e30c59d7e",
 		},
 
 		{
 			Protos: []proto.Message{
 				&api.Repetitive{Int64Field: []int64{123456789012345, 678901234567890}},
 			},
-			EquivalentObject:   map[string][]int64{"int64_field": {123456789012345, 678901234567890}},
-			ExpectedHashString: "e7c4423fe65d2f08",
+			EquivalentObject:         map[string][]int64{"int64_field": {123456789012345, 678901234567890}},
+			ExpectedHashString:       "e7c4423fe65d2f08",
+			ExpectedObjectHashString: "b0ce1b7dfa71b33a16571fea7f3f27341bf5980b040e9d949a8019f3143ecbc7",
+			ExpectedHashStringV2:     "9ff30186b5e449af",
 		},
 
 		/////////////////////////
@@ -128,63 +142,77 @@ func TestRepeatedFields(t *testing.T, hasher *ph.ProtoHasher) {
 			Protos: []proto.Message{
 				&api.Repetitive{FloatField: []float32{0}},
 			},
-			EquivalentJSONString: "{\"float_field\": [0]}",
-			EquivalentObject:     map[string][]float32{"float_field": {0}},
-			ExpectedHashString:   "88abed3eda001f87",
+			EquivalentJSONString:     "{\"float_field\": [0]}",
+			EquivalentObject:         map[string][]float32{"float_field": {0}},
+			ExpectedHashString:       "88abed3eda001f87",
+			ExpectedObjectHashString: "63b09f87ed057a88b38e2a69b6dde327d9e2624384542853327d6b90c83046f9",
+			ExpectedHashStringV2:     "a8a0d21e3316f797",
 		},
 
 		{
 			Protos: []proto.Message{
 				&api.Repetitive{FloatField: []float32{0.0}},
 			},
-			EquivalentJSONString: "{\"float_field\": [0.0]}",
-			EquivalentObject:     map[string][]float32{"float_field": {0.0}},
-			ExpectedHashString:   "88abed3eda001f87",
+			EquivalentJSONString:     "{\"float_field\": [0.0]}",
+			EquivalentObject:         map[string][]float32{"float_field": {0.0}},
+			ExpectedHashString:       "88abed3eda001f87",
+			ExpectedObjectHashString: "63b09f87ed057a88b38e2a69b6dde327d9e2624384542853327d6b90c83046f9",
+			ExpectedHashStringV2:     "a8a0d21e3316f797",
 		},
 
 		{
 			Protos: []proto.Message{
 				&api.Repetitive{FloatField: []float32{-2, -1, 0, 1, 2}},
 			},
-			EquivalentJSONString: "{\"float_field\": [-2, -1, 0, 1, 2]}",
-			EquivalentObject:     map[string][]float32{"float_field": {-2, -1, 0, 1, 2}},
-			ExpectedHashString:   "3df0da89a348c288",
+			EquivalentJSONString:     "{\"float_field\": [-2, -1, 0, 1, 2]}",
+			EquivalentObject:         map[string][]float32{"float_field": {-2, -1, 0, 1, 2}},
+			ExpectedHashString:       "3df0da89a348c288",
+			ExpectedObjectHashString: "68b2552f2f33b5dd38c9be0aeee127170c86d8d2b3ab7daebdc2ea124226593f",
+			ExpectedHashStringV2:     "25bceb2d6cff635b",
 		},
 
 		{
 			Protos: []proto.Message{
 				&api.Repetitive{FloatField: []float32{1, 2, 3}},
 			},
-			EquivalentJSONString: "{\"float_field\": [1, 2, 3]}",
-			EquivalentObject:     map[string][]float32{"float_field": {1, 2, 3}},
-			ExpectedHashString:   "96c4f986cedc148",
+			EquivalentJSONString:     "{\"float_field\": [1, 2, 3]}",
+			EquivalentObject:         map[string][]float32{"float_field": {1, 2, 3}},
+			ExpectedHashString:       "96c4f986cedc148",
+			ExpectedObjectHashString: "f26c1502d1f9f7bf672cf669290348f9bfdea0af48261f2822aad01927fe1749",
+			ExpectedHashStringV2:     "f7268ec166bf661f",
 		},
 
 		{
 			Protos: []proto.Message{
 				&api.Repetitive{DoubleField: []float64{1.2345, -10.1234}},
 			},
-			EquivalentJSONString: "{\"double_field\": [1.2345, -10.1234]}",
-			EquivalentObject:     map[string][]float64{"double_field": {1.2345, -10.1234}},
-			ExpectedHashString:   "d317c8afdac508cc",
+			EquivalentJSONString:     "{\"double_field\": [1.2345, -10.1234]}",
+			EquivalentObject:         map[string][]float64{"double_field": {1.2345, -10.1234}},
+			ExpectedHashString:       "d317c8afdac508cc",
+			ExpectedObjectHashString: "2e60f6cdebfeb5e705666e9b0ff0ec652320ae27d77ad89bd4c7ddc632d0b93c",
+			ExpectedHashStringV2:     "b3361c39ca63a060",
 		},
 
 		{
 			Protos: []proto.Message{
 				&api.Repetitive{DoubleField: []float64{1.0, 1.5, 0.0001, 1000.9999999, 2.0, -23.1234, 2.32542}},
 			},
-			EquivalentJSONString: "{\"double_field\": [1.0, 1.5, 0.0001, 1000.9999999, 2.0, -23.1234, 2.32542]}",
-			EquivalentObject:     map[string][]float64{"double_field": {1.0, 1.5, 0.0001, 1000.9999999, 2.0, -23.1234, 2.32542}},
-			ExpectedHashString:   "ca0d702cfcb510b9",
+			EquivalentJSONString:     "{\"double_field\": [1.0, 1.5, 0.0001, 1000.9999999, 2.0, -23.1234, 2.32542]}",
+			EquivalentObject:         map[string][]float64{"double_field": {1.0, 1.5, 0.0001, 1000.9999999, 2.0, -23.1234, 2.32542}},
+			ExpectedHashString:       "ca0d702cfcb510b9",
+			ExpectedObjectHashString: "09a46866ca2c6d406513cd6e25feb6eda7aef4d25259f5ec16bf72f1f8bbcdac",
+			ExpectedHashStringV2:     "ce483bcab00d6d79",
 		},
 
 		{
 			Protos: []proto.Message{
 				&api.Repetitive{DoubleField: []float64{123456789012345, 678901234567890}},
 			},
-			EquivalentJSONString: "{\"double_field\": [123456789012345, 678901234567890]}",
-			EquivalentObject:     map[string][]float64{"double_field": {123456789012345, 678901234567890}},
-			ExpectedHashString:   "66ed2a9a6f6b8684",
+			EquivalentJSONString:     "{\"double_field\": [123456789012345, 678901234567890]}",
+			EquivalentObject:         map[string][]float64{"double_field": {123456789012345, 678901234567890}},
+			ExpectedHashString:       "66ed2a9a6f6b8684",
+			ExpectedObjectHashString: "067d25d39b8514b6b905e0eba2d19242bcf4441e2367527dbceac7a9dd0108a0",
+			ExpectedHashStringV2:     "55ae9766085d7191",
 		},
 	}
 
@@ -0,0 +1,97 @@
+package tests
+
+import (
+	"testing"
+
+	ph "github.com/aserto-dev/go-protohash"
+	"github.com/aserto-dev/go-protohash/tests/api/v1"
+	tc "github.com/aserto-dev/go-protohash/tests/internal"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// TestSchemaEvolution performs tests on a hasher created with
+// ph.WithSchemaEvolution, over successive versions of the People messages.
+func TestSchemaEvolution(t *testing.T, hasher *ph.ProtoHasher) {
+
+	testCases := []tc.TestCase{
+		// Adding fields does not change the hash of messages that leave them
+		// unset, and renaming them does not change it either.
+		{
+			Protos: []proto.Message{
+				&api.PersonV1{Id: 1, Name: "Alice"},
+				&api.PersonV2{Id: 1, Name: "Alice"},
+				&api.PersonV4{Id: 1, DeprecatedFullName: "Alice"},
+			},
+			ExpectedHashStringV2: "4b0b95d15ac8ad83",
+		},
+		{
+			Protos: []proto.Message{
+				&api.PersonV2{Id: 1, Age: 30, Profession: "Engineer"},
+				&api.PersonV3{Id: 1, Age: 30, Profession: "Engineer"},
+				&api.PersonV4{Id: 1, Age: 30, Profession: "Engineer"},
+			},
+		},
+
+		// Renaming the type of a message field does not change the hash.
+		{
+			Protos: []proto.Message{
+				&api.PersonV2{Id: 1, Children: []*api.PersonV2{{Id: 2}}},
+				&api.PersonV3{Id: 1, Children: []*api.PersonV3{{Id: 2}}},
+				&api.PersonV4{Id: 1, Children: []*api.PersonV3{{Id: 2}}},
+			},
+		},
+		{
+			Protos: []proto.Message{
+				&api.PersonV3{Id: 1, Children: []*api.PersonV3{{Id: 2, Name: &api.PersonV3_FullName{FullName: "Bob"}}}},
+				&api.PersonV4{Id: 1, Children: []*api.PersonV3{{Id: 2, Name: &api.PersonV3_FullName{FullName: "Bob"}}}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc.Check(t, hasher)
+	}
+
+	// Moving a value into a oneof or into a submessage changes the hash, as
+	// does moving it to another field number. Moving a submessage out of a
+	// oneof changes it as well.
+	tc.CheckDistinct(t, hasher,
+		&api.PersonV2{Id: 1, Name: "Alice"},
+		&api.PersonV3{Id: 1, Name: &api.PersonV3_FullName{FullName: "Alice"}},
+		&api.PersonV3{Id: 1, Name: &api.PersonV3_StructuredName{StructuredName: &api.PersonV3_NameV3{First: "Alice"}}},
+		&api.PersonV4{Id: 1, StructuredName: &api.PersonV4_NameV4{First: "Alice"}},
+		&api.PersonV2{Id: 1, Profession: "Alice"},
+		&api.PersonV2{Age: 1, Name: "Alice"},
+	)
+	tc.CheckDistinct(t, hasher,
+		&api.PersonV2{Id: 1, Children: []*api.PersonV2{{Id: 2, Name: "Bob"}}},
+		&api.PersonV3{Id: 1, Children: []*api.PersonV3{{Id: 2, Name: &api.PersonV3_FullName{FullName: "Bob"}}}},
+	)
+
+	// The change is predictable, as the value of a oneof field is bound to a
+	// reserved number before its own: its hash is pinned.
+	tc.TestCase{
+		Protos: []proto.Message{
+			&api.PersonV3{Id: 1, Name: &api.PersonV3_FullName{FullName: "Alice"}},
+		},
+		ExpectedHashStringV2: "cc58b520c6c511fb",
+	}.Check(t, hasher)
+
+	// The submessage is hashed as a message value of the number of its field:
+	// the field holds the hash of the message, which is combined with the
+	// other fields as any field value is.
+	moved := &api.PersonV4{Id: 1, StructuredName: &api.PersonV4_NameV4{First: "Alice", Last: "Smith"}}
+	tree, err := hasher.HashTree(moved)
+	if err != nil {
+		t.Fatalf("Attempting to hash the tree of %T{ %[1]v } returned an error: %v", moved, err)
+	}
+	nameHash, err := hasher.HashMessage(moved.StructuredName)
+	if err != nil {
+		t.Fatalf("Attempting to hash %T{ %[1]v } returned an error: %v", moved.StructuredName, err)
+	}
+	field := tree.Children[len(tree.Children)-1]
+	if field.Step.FieldDescriptor().Number() != protoreflect.FieldNumber(6) || field.Hash != nameHash {
+		t.Errorf("The hash of %s is %x, expected the hash %x of field 6", field.Step, field.Hash, nameHash)
+	}
+}
@@ -17,16 +17,20 @@ func TestStringFields(t *testing.T, hasher *ph.ProtoHasher) {
 			Protos: []proto.Message{
 				&api.Simple{StringField: "你好"},
 			},
-			ExpectedHashString: "e2dd2a3d97f401ac",
+			ExpectedHashString:       "e2dd2a3d97f401ac",
+			ExpectedObjectHashString: "de0086ad683b5f8affffbbcbe57d09e5377aa47cb32f6f0b1bdecd2e54b9137d",
+			ExpectedHashStringV2:     "927fec7ca83c0d87",
 		},
 
 		{
 			Protos: []proto.Message{
 				&api.Simple{StringField: "\u03d3"},
 			},
-			EquivalentObject:     map[string]string{"string_field": "\u03d3"},
-			EquivalentJSONString: "{\"string_field\":\"\u03d3\"}",
-			ExpectedHashString:   "889bf3c60923cb21",
+			EquivalentObject:         map[string]string{"string_field": "\u03d3"},
+			EquivalentJSONString:     "{\"string_field\":\"\u03d3\"}",
+			ExpectedHashString:       "889bf3c60923cb21",
+			ExpectedObjectHashString: "12441188aebffcc3a1e625d825391678d8417c77e645fc992d1ab5b549c659a7",
+			ExpectedHashStringV2:     "89ce92d34bdbae4f",
 		},
 
 		// Note that this is the same character as above, but hashes differently
@@ -35,27 +39,33 @@ func TestStringFields(t *testing.T, hasher *ph.ProtoHasher) {
 			Protos: []proto.Message{
 				&api.Simple{StringField: "\u03d2\u0301"},
 			},
-			EquivalentObject:     map[string]string{"string_field": "\u03d2\u0301"},
-			EquivalentJSONString: "{\"string_field\":\"\u03d2\u0301\"}",
-			ExpectedHashString:   "6b17ebd06d7ba11d",
+			EquivalentObject:         map[string]string{"string_field": "\u03d2\u0301"},
+			EquivalentJSONString:     "{\"string_field\":\"\u03d2\u0301\"}",
+			ExpectedHashString:       "6b17ebd06d7ba11d",
+			ExpectedObjectHashString: "1f33a91552e7a527fdf2de0d25f815590f1a3e2dc8340507d20d4ee42462d0a2",
+			ExpectedHashStringV2:     "1aa6faffe13fd78",
 		},
 
 		{
 			Protos: []proto.Message{
 				&api.Repetitive{StringField: []string{""}},
 			},
-			EquivalentObject:     map[string][]string{"string_field": {""}},
-			EquivalentJSONString: "{\"string_field\":[\"\"]}",
-			ExpectedHashString:   "bab48eecfa8cd51a",
+			EquivalentObject:         map[string][]string{"string_field": {""}},
+			EquivalentJSONString:     "{\"string_field\":[\"\"]}",
+			ExpectedHashString:       "bab48eecfa8cd51a",
+			ExpectedObjectHashString: "63e64f0ed286e0d8f30735e6646ea9ef48174c23ba09a05288b4233c6e6a9419",
+			ExpectedHashStringV2:     "ad243438bbdbfd32",
 		},
 
 		{
 			Protos: []proto.Message{
 				&api.Repetitive{StringField: []string{"", "Test", "你好", "\u03d3"}},
 			},
-			EquivalentObject:     map[string][]string{"string_field": {"", "Test", "你好", "\u03d3"}},
-			EquivalentJSONString: "{\"string_field\":[\"\",\"Test\",\"你好\",\"\u03d3\"]}",
-			ExpectedHashString:   "ee1bfab42da1d7fe",
+			EquivalentObject:         map[string][]string{"string_field": {"", "Test", "你好", "\u03d3"}},
+			EquivalentJSONString:     "{\"string_field\":[\"\",\"Test\",\"你好\",\"\u03d3\"]}",
+			ExpectedHashString:       "ee1bfab42da1d7fe",
+			ExpectedObjectHashString: "f76ae15a2685a5ec0e45f9ad7d75e492e6a17d31811480fbaf00af451fb4e98e",
+			ExpectedHashStringV2:     "477116fcc0fce7c7",
 		},
 	}
 
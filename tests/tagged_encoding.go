@@ -0,0 +1,103 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	ph "github.com/aserto-dev/go-protohash"
+	"github.com/aserto-dev/go-protohash/tests/api/v1"
+	ti "github.com/aserto-dev/go-protohash/tests/internal"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// TestTaggedEncoding checks that values of different kinds sharing the same
+// byte representation do not collide, while the kinds that are meant to be
+// equivalent still are. It is only meaningful for hashers using ph.WithTaggedEncoding.
+func TestTaggedEncoding(t *testing.T, hasher *ph.ProtoHasher) {
+
+	distinctCases := [][]proto.Message{
+		{
+			&api.Simple{StringField: "\x01\x00\x00\x00\x00\x00\x00\x00"},
+			&api.Simple{BytesField: []byte{1, 0, 0, 0, 0, 0, 0, 0}},
+			&api.Simple{Int64Field: 1},
+			&api.Simple{DoubleField: math.Float64frombits(1)},
+		},
+
+		{
+			&api.Simple{BoolField: true},
+			&api.Simple{StringField: "\x01"},
+			&api.Simple{BytesField: []byte{1}},
+		},
+
+		{
+			&api.Repetitive{StringField: []string{"foo"}},
+			&api.Repetitive{BytesField: [][]byte{[]byte("foo")}},
+		},
+
+		// Signed and unsigned integers of the same bits.
+		{
+			&api.Int64Message{Values: []int64{-1}},
+			&api.Uint64Message{Values: []uint64{math.MaxUint64}},
+		},
+
+		{
+			wrapperspb.Int64(-1),
+			wrapperspb.UInt64(math.MaxUint64),
+		},
+
+		{
+			&api.KnownTypes{Int64ValueField: wrapperspb.Int64(-1)},
+			&api.KnownTypes{Uint64ValueField: wrapperspb.UInt64(math.MaxUint64)},
+		},
+
+		{
+			&api.MyFavoritePlanets{Planets: []api.Planet{api.Planet_PLANET_EARTH}},
+			&api.Repetitive{StringField: []string{"\x03\x00\x00\x00"}},
+			&api.Repetitive{BytesField: [][]byte{{3, 0, 0, 0}}},
+		},
+	}
+
+	for _, protos := range distinctCases {
+		ti.CheckDistinct(t, hasher, protos...)
+	}
+
+	testCases := []ti.TestCase{
+		///////////////////////////////////////////////////////////
+		//  Signed and unsigned integer kinds share their tags. //
+		///////////////////////////////////////////////////////////
+		{
+			Protos: []proto.Message{
+				&api.Int32Message{Values: []int32{0, 1, 2}},
+				&api.Int64Message{Values: []int64{0, 1, 2}},
+				&api.Sfixed32Message{Values: []int32{0, 1, 2}},
+				&api.Sfixed64Message{Values: []int64{0, 1, 2}},
+				&api.Sint32Message{Values: []int32{0, 1, 2}},
+				&api.Sint64Message{Values: []int64{0, 1, 2}},
+			},
+		},
+
+		{
+			Protos: []proto.Message{
+				&api.Fixed32Message{Values: []uint32{0, 1, 2}},
+				&api.Fixed64Message{Values: []uint64{0, 1, 2}},
+				&api.Uint32Message{Values: []uint32{0, 1, 2}},
+				&api.Uint64Message{Values: []uint64{0, 1, 2}},
+			},
+		},
+
+		///////////////////////////////////////////
+		//  Float and double share the same tag. //
+		///////////////////////////////////////////
+		{
+			Protos: []proto.Message{
+				&api.DoubleMessage{Values: []float64{-2, -1, 0, 1, 2}},
+				&api.FloatMessage{Values: []float32{-2, -1, 0, 1, 2}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc.Check(t, hasher)
+	}
+}
@@ -48,8 +48,10 @@ func TestTimestamps(t *testing.T, hasher *ph.ProtoHasher) {
 				&timestamppb.Timestamp{Seconds: 0, Nanos: 0},
 			},
 			// JSON treats all numbers as floats, so it is not possible to have an equivalent JSON string.
-			EquivalentObject:   []int64{0, 0},
-			ExpectedHashString: "3a82b649344529f03f52c1833f5aecc488a53b31461a1f54c305d149b12b8f53",
+			EquivalentObject:         []int64{0, 0},
+			ExpectedHashString:       "88201fb960ff6465",
+			ExpectedHashStringV2:     "88201fb960ff6465",
+			UpstreamObjectHashString: "3a82b649344529f03f52c1833f5aecc488a53b31461a1f54c305d149b12b8f53",
 		},
 
 		/////////////////////////
@@ -60,8 +62,10 @@ func TestTimestamps(t *testing.T, hasher *ph.ProtoHasher) {
 				&timestamppb.Timestamp{Seconds: 1525450021, Nanos: 123456789},
 			},
 			// JSON treats all numbers as floats, so it is not possible to have an equivalent JSON string.
-			EquivalentObject:   []int64{1525450021, 123456789},
-			ExpectedHashString: "1fd36770664df599ad44e4e4f06b1fad6ef7a4b3f316d79ca11bea668032a199",
+			EquivalentObject:         []int64{1525450021, 123456789},
+			ExpectedHashString:       "cfce72488a327ee5",
+			ExpectedHashStringV2:     "cfce72488a327ee5",
+			UpstreamObjectHashString: "1fd36770664df599ad44e4e4f06b1fad6ef7a4b3f316d79ca11bea668032a199",
 		},
 
 		//////////////////////////////////////
@@ -76,8 +80,10 @@ func TestTimestamps(t *testing.T, hasher *ph.ProtoHasher) {
 				&api.KnownTypes{TimestampField: &timestamppb.Timestamp{Seconds: 0, Nanos: 0}},
 			},
 			// JSON treats all numbers as floats, so it is not possible to have an equivalent JSON string.
-			EquivalentObject:   map[string][]int64{"timestamp_field": {0, 0}},
-			ExpectedHashString: "8457fe431752dbc5c47301c2546fcf6f0ad8c5317092b443e187d18e312e497e",
+			EquivalentObject:         map[string][]int64{"timestamp_field": {0, 0}},
+			ExpectedHashString:       "661a6df2c7688a1b",
+			ExpectedHashStringV2:     "ba51714115dc1923",
+			UpstreamObjectHashString: "8457fe431752dbc5c47301c2546fcf6f0ad8c5317092b443e187d18e312e497e",
 		},
 
 		{
@@ -85,8 +91,10 @@ func TestTimestamps(t *testing.T, hasher *ph.ProtoHasher) {
 				&api.KnownTypes{TimestampField: &timestamppb.Timestamp{Seconds: 1525450021, Nanos: 123456789}},
 			},
 			// JSON treats all numbers as floats, so it is not possible to have an equivalent JSON string.
-			EquivalentObject:   map[string][]int64{"timestamp_field": {1525450021, 123456789}},
-			ExpectedHashString: "cf99942e3f8d1212f4ce263e206d64e29525b97b91368e71f9595bce83ac6a3e",
+			EquivalentObject:         map[string][]int64{"timestamp_field": {1525450021, 123456789}},
+			ExpectedHashString:       "20869a3722717037",
+			ExpectedHashStringV2:     "de8dd4a547d6ad48",
+			UpstreamObjectHashString: "cf99942e3f8d1212f4ce263e206d64e29525b97b91368e71f9595bce83ac6a3e",
 		},
 	}
 
@@ -0,0 +1,59 @@
+package tests
+
+import (
+	"testing"
+
+	ph "github.com/aserto-dev/go-protohash"
+	"github.com/aserto-dev/go-protohash/tests/api/v1"
+	tc "github.com/aserto-dev/go-protohash/tests/internal"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// TestTypeNames performs tests on a hasher created with ph.WithTypeNames.
+func TestTypeNames(t *testing.T, hasher *ph.ProtoHasher) {
+
+	// Empty messages hash to a non-zero sentinel, distinct for every type.
+	empty := []proto.Message{
+		&api.Empty{},
+		&api.Singleton{},
+		&api.Simple{},
+		&api.Repetitive{},
+		&api.PersonV1{},
+		&api.PersonV2{},
+	}
+	for _, message := range empty {
+		messageHash, err := hasher.HashMessage(message)
+		if err != nil {
+			t.Errorf("Attempting to hash %T{ %[1]v } returned an error: %v", message, err)
+		}
+		if messageHash == 0 {
+			t.Errorf("The empty %T hashes to 0", message)
+		}
+	}
+	tc.CheckDistinct(t, hasher, empty...)
+
+	// Messages of different types holding the same fields hash differently,
+	// at any depth.
+	tc.CheckDistinct(t, hasher,
+		&api.PersonV1{Id: 1, Name: "Alice"},
+		&api.PersonV2{Id: 1, Name: "Alice"},
+		&api.Simple{SimpleField: &api.Simple{}},
+		&api.Simple{RepetitiveField: &api.Repetitive{}},
+		&api.Simple{SingletonField: &api.Singleton{}},
+		&api.KnownTypes{Int32ValueField: wrapperspb.Int32(1)},
+		&api.KnownTypes{Int64ValueField: wrapperspb.Int64(1)},
+	)
+
+	// Equal messages still hash alike, however they are hashed.
+	testCases := []tc.TestCase{
+		{Protos: []proto.Message{&api.Empty{}}},
+		{Protos: []proto.Message{&api.PersonV2{Id: 1, Name: "Alice", Children: []*api.PersonV2{{Id: 2}}}}},
+		{Protos: []proto.Message{&api.KnownTypes{TimestampField: timestamppb.New(timestamppb.Now().AsTime())}}},
+		{Protos: []proto.Message{&api.Simple{SimpleField: &api.Simple{}, SingletonField: &api.Singleton{}}}},
+	}
+	for _, tc := range testCases {
+		tc.Check(t, hasher)
+	}
+}
@@ -0,0 +1,134 @@
+package tests
+
+import (
+	"testing"
+
+	ph "github.com/aserto-dev/go-protohash"
+	pb2 "github.com/aserto-dev/go-protohash/tests/api/proto2/v1"
+	"github.com/aserto-dev/go-protohash/tests/api/v1"
+	tc "github.com/aserto-dev/go-protohash/tests/internal"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestUnknownFields performs tests on a hasher created with
+// ph.WithUnknownFields.
+func TestUnknownFields(t *testing.T, hasher *ph.ProtoHasher) {
+
+	unknown := func(fields ...[]byte) proto.Message {
+		var b []byte
+		for _, f := range fields {
+			b = append(b, f...)
+		}
+		m := &pb2.Empty{}
+		m.ProtoReflect().SetUnknown(b)
+		return m
+	}
+	varint := func(num protowire.Number, v uint64) []byte {
+		return protowire.AppendVarint(protowire.AppendTag(nil, num, protowire.VarintType), v)
+	}
+	fixed64 := func(num protowire.Number, v uint64) []byte {
+		return protowire.AppendFixed64(protowire.AppendTag(nil, num, protowire.Fixed64Type), v)
+	}
+	str := func(num protowire.Number, v string) []byte {
+		return protowire.AppendString(protowire.AppendTag(nil, num, protowire.BytesType), v)
+	}
+	reparse := func(from, to proto.Message) proto.Message {
+		b, err := proto.Marshal(from)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := proto.Unmarshal(b, to); err != nil {
+			t.Fatal(err)
+		}
+		return to
+	}
+
+	testCases := []tc.TestCase{
+		// Messages without unknown fields hash as they do without the option.
+		{
+			Protos:               []proto.Message{&pb2.Empty{}},
+			ExpectedHashString:   "0",
+			ExpectedHashStringV2: "0",
+		},
+		{
+			Protos:               []proto.Message{&api.DoubleMessage{Value: 0.1}},
+			ExpectedHashString:   "3703e1c494c5c8e9",
+			ExpectedHashStringV2: "3407bfedf4a21710",
+		},
+
+		// Unknown fields are sorted by field number.
+		{
+			Protos: []proto.Message{
+				unknown(varint(1, 1), str(2, "a"), str(2, "b")),
+				unknown(str(2, "a"), varint(1, 1), str(2, "b")),
+				unknown(str(2, "a"), str(2, "b"), varint(1, 1)),
+			},
+		},
+
+		// Messages whose unknown fields are the same hash alike.
+		{
+			Protos: []proto.Message{
+				tc.ForgetAllFields(t, &pb2.Simple{Int32Field: proto.Int32(1), StringField: proto.String("a")}),
+				tc.ForgetAllFields(t, &api.Simple{Int32Field: 1, StringField: "a"}),
+			},
+		},
+		{
+			Protos: []proto.Message{
+				tc.ForgetAllFields(t, &pb2.Grouped{Data: &pb2.Grouped_Data{Value: proto.Int32(1)}}),
+			},
+		},
+		{
+			Protos: []proto.Message{
+				reparse(&api.PersonV2{Id: 1, Name: "Alice"}, &api.PersonV1{}),
+				&api.PersonV1{Id: 1, Name: "Alice"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc.Check(t, hasher)
+	}
+
+	// Messages that differ by their unknown fields hash differently, whatever
+	// the number, wire type, value or order of the values of the fields.
+	tc.CheckDistinct(t, hasher,
+		&pb2.Empty{},
+		tc.ForgetAllFields(t, &pb2.Simple{Int32Field: proto.Int32(1)}),
+		tc.ForgetAllFields(t, &pb2.Simple{Int32Field: proto.Int32(2)}),
+		tc.ForgetAllFields(t, &pb2.Simple{Int64Field: proto.Int64(1)}),
+		tc.ForgetAllFields(t, &pb2.Simple{StringField: proto.String("a")}),
+		tc.ForgetAllFields(t, &pb2.Simple{BytesField: []byte("a")}),
+		tc.ForgetAllFields(t, &pb2.Grouped{Data: &pb2.Grouped_Data{Value: proto.Int32(1)}}),
+		tc.ForgetAllFields(t, &pb2.Grouped{Data: &pb2.Grouped_Data{Value: proto.Int32(2)}}),
+		unknown(fixed64(13, 1)),
+		unknown(str(2, "a"), str(2, "b")),
+		unknown(str(2, "b"), str(2, "a")),
+	)
+
+	// Data set by newer versions of a message is told apart, at any depth and
+	// whether or not the message has a generated ProtoHash method.
+	tc.CheckDistinct(t, hasher,
+		reparse(&api.PersonV2{Id: 1, Name: "Alice", Age: 30}, &api.PersonV1{}),
+		reparse(&api.PersonV2{Id: 1, Name: "Alice", Age: 31}, &api.PersonV1{}),
+		&api.PersonV1{Id: 1, Name: "Alice"},
+	)
+	tc.CheckDistinct(t, hasher,
+		reparse(&api.PersonV4{Children: []*api.PersonV3{{Name: &api.PersonV3_StructuredName{StructuredName: &api.PersonV3_NameV3{First: "Alice"}}}}}, &api.PersonV2{}),
+		reparse(&api.PersonV4{Children: []*api.PersonV3{{Name: &api.PersonV3_StructuredName{StructuredName: &api.PersonV3_NameV3{First: "Bob"}}}}}, &api.PersonV2{}),
+	)
+
+	// Without the option, they collide.
+	plain := ph.New(ph.WithAlgorithm(hasher.Algorithm()))
+	tc.TestCase{
+		Protos: []proto.Message{
+			reparse(&api.PersonV2{Id: 1, Name: "Alice", Age: 30}, &api.PersonV1{}),
+			reparse(&api.PersonV2{Id: 1, Name: "Alice", Age: 31}, &api.PersonV1{}),
+		},
+	}.Check(t, plain)
+
+	// Malformed unknown fields cannot be hashed.
+	if _, err := hasher.HashMessage(unknown([]byte{0xff})); err == nil {
+		t.Errorf("Hashing malformed unknown fields did not fail")
+	}
+}
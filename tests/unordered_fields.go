@@ -0,0 +1,119 @@
+package tests
+
+import (
+	"testing"
+
+	ph "github.com/aserto-dev/go-protohash"
+	"github.com/aserto-dev/go-protohash/tests/api/v1"
+	tc "github.com/aserto-dev/go-protohash/tests/internal"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// UnorderedFields are the fields that TestUnorderedFields expects to be hashed
+// as sets or multisets.
+var UnorderedFields = []protoreflect.FullName{
+	"tests.api.v1.Repetitive.int32_field",
+	"tests.api.v1.Repetitive.string_field",
+	"tests.api.v1.Repetitive.simple_field",
+}
+
+// TestUnorderedFields performs tests on repeated fields hashed as sets by set,
+// and as multisets by multiset, both created with ph.WithUnorderedFields and
+// UnorderedFields.
+func TestUnorderedFields(t *testing.T, set, multiset *ph.ProtoHasher) {
+
+	a, b := &api.Simple{StringField: "a"}, &api.Simple{Int32Field: 1}
+
+	// Both sets and multisets ignore the order of the elements.
+	testCases := []tc.TestCase{
+		{
+			Protos: []proto.Message{
+				&api.Repetitive{StringField: []string{"a", "b", "c"}},
+				&api.Repetitive{StringField: []string{"c", "a", "b"}},
+				&api.Repetitive{StringField: []string{"b", "c", "a"}},
+			},
+		},
+		{
+			Protos: []proto.Message{
+				&api.Repetitive{Int32Field: []int32{-1, 0, 1}, StringField: []string{"x", "y"}},
+				&api.Repetitive{Int32Field: []int32{1, -1, 0}, StringField: []string{"y", "x"}},
+			},
+		},
+		{
+			Protos: []proto.Message{
+				&api.Repetitive{SimpleField: []*api.Simple{a, b}},
+				&api.Repetitive{SimpleField: []*api.Simple{b, a}},
+			},
+		},
+
+		// Nested messages with unordered fields are hashed alike, even by the
+		// generated methods of the messages that contain them.
+		{
+			Protos: []proto.Message{
+				&api.Simple{RepetitiveField: &api.Repetitive{StringField: []string{"a", "b"}}},
+				&api.Simple{RepetitiveField: &api.Repetitive{StringField: []string{"b", "a"}}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc.Check(t, set)
+		tc.Check(t, multiset)
+	}
+
+	// Sets ignore duplicates.
+	tc.TestCase{
+		Protos: []proto.Message{
+			&api.Repetitive{StringField: []string{"a", "b"}},
+			&api.Repetitive{StringField: []string{"a", "b", "a"}},
+			&api.Repetitive{StringField: []string{"b", "b", "a", "a"}},
+		},
+	}.Check(t, set)
+	tc.TestCase{
+		Protos: []proto.Message{
+			&api.Repetitive{SimpleField: []*api.Simple{a, b}},
+			&api.Repetitive{SimpleField: []*api.Simple{b, a, b}},
+		},
+	}.Check(t, set)
+
+	// Multisets count duplicates.
+	tc.TestCase{
+		Protos: []proto.Message{
+			&api.Repetitive{StringField: []string{"a", "b", "a"}},
+			&api.Repetitive{StringField: []string{"a", "a", "b"}},
+		},
+	}.Check(t, multiset)
+
+	// Duplicates do not cancel out, unlike the entries of maps, and fields that
+	// are not marked keep their order.
+	distinct := []proto.Message{
+		&api.Repetitive{StringField: []string{"a"}},
+		&api.Repetitive{StringField: []string{"b"}},
+		&api.Repetitive{StringField: []string{"a", "b"}},
+		&api.Repetitive{StringField: []string{"a", "c"}},
+		&api.Repetitive{Int32Field: []int32{1}},
+		&api.Repetitive{Int32Field: []int32{1, 2}},
+		&api.Repetitive{Uint32Field: []uint32{1, 2}},
+		&api.Repetitive{Uint32Field: []uint32{2, 1}},
+	}
+	tc.CheckDistinct(t, set, distinct...)
+	tc.CheckDistinct(t, multiset, append(distinct,
+		&api.Repetitive{StringField: []string{"a", "a"}},
+		&api.Repetitive{StringField: []string{"a", "a", "b"}},
+		&api.Repetitive{StringField: []string{"a", "b", "b"}},
+		&api.Repetitive{StringField: []string{"b", "b"}},
+	)...)
+
+	// Elements hashing to zero, such as empty messages, are counted as well.
+	tc.CheckDistinct(t, multiset,
+		&api.Repetitive{},
+		&api.Repetitive{SimpleField: []*api.Simple{{}}},
+		&api.Repetitive{SimpleField: []*api.Simple{{}, {}}},
+		&api.Repetitive{SimpleField: []*api.Simple{{}, {}, {}}},
+	)
+	tc.CheckDistinct(t, set,
+		&api.Repetitive{},
+		&api.Repetitive{SimpleField: []*api.Simple{{}}},
+	)
+}
@@ -0,0 +1,250 @@
+package tests
+
+import (
+	"testing"
+
+	ph "github.com/aserto-dev/go-protohash"
+	"github.com/aserto-dev/go-protohash/tests/api/v1"
+	ti "github.com/aserto-dev/go-protohash/tests/internal"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// TestWellKnownTypes performs tests on how durations, wrappers and the JSON-like
+// well-known types are handled.
+func TestWellKnownTypes(t *testing.T, hasher *ph.ProtoHasher) {
+
+	testCases := []ti.TestCase{
+		////////////////
+		//  Durations. //
+		////////////////
+
+		// Like timestamps, an empty duration is explicitly set to zero.
+		{
+			Protos: []proto.Message{
+				&durationpb.Duration{},
+				&durationpb.Duration{Seconds: 0, Nanos: 0},
+			},
+			// JSON treats all numbers as floats, so it is not possible to have an equivalent JSON string.
+			EquivalentObject:         []int64{0, 0},
+			ExpectedHashString:       "88201fb960ff6465",
+			ExpectedHashStringV2:     "88201fb960ff6465",
+			ExpectedObjectHashString: "3a82b649344529f03f52c1833f5aecc488a53b31461a1f54c305d149b12b8f53",
+		},
+
+		// Durations are normalized before they are hashed.
+		{
+			Protos: []proto.Message{
+				&durationpb.Duration{Seconds: 90, Nanos: 500000000},
+				&durationpb.Duration{Seconds: 89, Nanos: 1500000000},
+				&durationpb.Duration{Seconds: 91, Nanos: -500000000},
+			},
+			EquivalentObject:         []int64{90, 500000000},
+			ExpectedHashString:       "de997aa8ff7f1916",
+			ExpectedHashStringV2:     "de997aa8ff7f1916",
+			ExpectedObjectHashString: "d9dc93607a436d66441e302ef700e559953961c05ad78caad3c32950077b1699",
+		},
+
+		{
+			Protos: []proto.Message{
+				&api.KnownTypes{DurationField: &durationpb.Duration{Seconds: -1, Nanos: -1}},
+				&api.KnownTypes{DurationField: &durationpb.Duration{Seconds: -2, Nanos: 999999999}},
+			},
+			EquivalentObject:         map[string][]int64{"duration_field": {-1, -1}},
+			ExpectedHashString:       "babf415b1a3860d7",
+			ExpectedHashStringV2:     "659034e14d30cb22",
+			ExpectedObjectHashString: "5a91195d4dca04f6c437b2c59ed52bad1d7bf45ea47c15217e4095a233c153fe",
+		},
+
+		///////////////
+		//  Wrappers. //
+		///////////////
+
+		// A wrapper is hashed as its value, which is present even when it is zero.
+		{
+			Protos: []proto.Message{
+				&api.KnownTypes{Int32ValueField: wrapperspb.Int32(0)},
+				&api.KnownTypes{Int32ValueField: &wrapperspb.Int32Value{}},
+			},
+			EquivalentObject:         map[string]int32{"int32_value_field": 0},
+			ExpectedHashString:       "cb720bf58a2c29ec",
+			ExpectedHashStringV2:     "8a0d89850abe1f00",
+			ExpectedObjectHashString: "f45c9b89d9a758f70fee58bad947bca07bd20a31119d927588e7bb11ef17180d",
+		},
+
+		{
+			Protos: []proto.Message{
+				&api.KnownTypes{StringValueField: wrapperspb.String("")},
+			},
+			EquivalentJSONString:     "{\"string_value_field\": \"\"}",
+			EquivalentObject:         map[string]string{"string_value_field": ""},
+			ExpectedHashString:       "5fd4b748b2f5442c",
+			ExpectedHashStringV2:     "164c55e3652b50c6",
+			ExpectedObjectHashString: "2ce75d087e557a68b232652d48e6aac5f3fc457c597a0ed07a1b63a4c2d16039",
+		},
+
+		{
+			Protos: []proto.Message{
+				&api.KnownTypes{
+					BoolValueField:   wrapperspb.Bool(true),
+					BytesValueField:  wrapperspb.Bytes([]byte("bytes")),
+					DoubleValueField: wrapperspb.Double(1.5),
+					FloatValueField:  wrapperspb.Float(2.5),
+					Int64ValueField:  wrapperspb.Int64(-64),
+					Uint32ValueField: wrapperspb.UInt32(32),
+					Uint64ValueField: wrapperspb.UInt64(64),
+				},
+			},
+			EquivalentObject: map[string]interface{}{
+				"bool_value_field":   true,
+				"bytes_value_field":  []byte("bytes"),
+				"double_value_field": 1.5,
+				"float_value_field":  2.5,
+				"int64_value_field":  -64,
+				"uint32_value_field": 32,
+				"uint64_value_field": 64,
+			},
+			ExpectedHashString:       "dbb47944c267480a",
+			ExpectedHashStringV2:     "8d25325821d027d2",
+			ExpectedObjectHashString: "4e2b4adf1a17f161de9effa7679d874d911f8c229374ec53d028349677df0c15",
+		},
+
+		///////////////////////////////////
+		//  Struct, Value and ListValue. //
+		///////////////////////////////////
+		{
+			Protos: []proto.Message{
+				&structpb.Struct{},
+			},
+			EquivalentJSONString:     "{}",
+			ExpectedHashString:       "a8c7f832281a39c5",
+			ExpectedHashStringV2:     "a8c7f832281a39c5",
+			ExpectedObjectHashString: "18ac3e7343f016890c510e93f935261169d9e3f565436429830faf0934f4f8e4",
+		},
+
+		{
+			Protos: []proto.Message{
+				structpb.NewNullValue(),
+			},
+			EquivalentJSONString:     "null",
+			ExpectedHashString:       "af64724c8602eb6e",
+			ExpectedHashStringV2:     "af64724c8602eb6e",
+			ExpectedObjectHashString: "1b16b1df538ba12dc3f97edbb85caa7050d46c148134290feba80f8236c83db9",
+		},
+
+		{
+			Protos: []proto.Message{
+				structpb.NewNumberValue(0),
+			},
+			EquivalentJSONString:     "0",
+			ExpectedHashString:       "a8c7f832281a39c5",
+			ExpectedHashStringV2:     "a8c7f832281a39c5",
+			ExpectedObjectHashString: "60101d8c9cb988411468e38909571f357daa67bff5a7b0a3f9ae295cd4aba33d",
+		},
+
+		{
+			Protos: []proto.Message{
+				mustStruct(t, map[string]interface{}{
+					"null":   nil,
+					"bool":   false,
+					"number": 1.5,
+					"string": "foo",
+					"list":   []interface{}{1, "two", true, nil},
+					"struct": map[string]interface{}{"nested": "bar"},
+				}),
+			},
+			EquivalentJSONString: "{\"null\": null, \"bool\": false, \"number\": 1.5, \"string\": \"foo\", " +
+				"\"list\": [1, \"two\", true, null], \"struct\": {\"nested\": \"bar\"}}",
+			ExpectedHashString:       "1bd853f6fe764a95",
+			ExpectedHashStringV2:     "1bd853f6fe764a95",
+			ExpectedObjectHashString: "dd8a69a865583ccc18cd1a50443e293ba6c8e6d13d5749f28e21f27c461081e7",
+		},
+
+		{
+			Protos: []proto.Message{
+				&api.KnownTypes{ListValueField: &structpb.ListValue{Values: []*structpb.Value{
+					structpb.NewStringValue("a"),
+					structpb.NewListValue(&structpb.ListValue{}),
+				}}},
+			},
+			EquivalentJSONString:     "{\"list_value_field\": [\"a\", []]}",
+			ExpectedHashString:       "a98a51b95c4ba378",
+			ExpectedHashStringV2:     "a9905657512fc0fd",
+			ExpectedObjectHashString: "b2a7e8f23f244b900880ba91e16613a6e02c35d1e6637f8a1a2cb679103a770a",
+		},
+
+		// A Value holding a struct hashes like the Struct itself.
+		{
+			Protos: []proto.Message{
+				structpb.NewStructValue(mustStruct(t, map[string]interface{}{"a": 1})),
+				mustStruct(t, map[string]interface{}{"a": 1}),
+			},
+			EquivalentJSONString:     "{\"a\": 1}",
+			ExpectedHashString:       "2c049560292da724",
+			ExpectedHashStringV2:     "2c049560292da724",
+			ExpectedObjectHashString: "523ef52bdd3566aefa440b297796d7b1367a2e82d922165571f4326381635c14",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc.Check(t, hasher)
+	}
+
+	distinctCases := [][]proto.Message{
+		{
+			&api.KnownTypes{},
+			&api.KnownTypes{Int32ValueField: wrapperspb.Int32(0)},
+			&api.KnownTypes{StringValueField: wrapperspb.String("")},
+			&api.KnownTypes{DurationField: &durationpb.Duration{}},
+		},
+
+		// Null has a representation of its own, even without
+		// ph.WithTaggedEncoding.
+		{
+			structpb.NewNullValue(),
+			structpb.NewStringValue(""),
+			structpb.NewStringValue("n"),
+			structpb.NewNumberValue(0),
+			structpb.NewBoolValue(false),
+			structpb.NewListValue(&structpb.ListValue{}),
+		},
+		{
+			&api.KnownTypes{ValueField: structpb.NewNullValue()},
+			&api.KnownTypes{ValueField: structpb.NewStringValue("")},
+		},
+	}
+
+	for _, protos := range distinctCases {
+		ti.CheckDistinct(t, hasher, protos...)
+	}
+}
+
+// TestUnsupportedWellKnownTypes checks that the well-known types that have no
+// ObjectHash representation are rejected by ObjectHash.
+func TestUnsupportedWellKnownTypes(t *testing.T, hasher *ph.ProtoHasher) {
+
+	testCases := []proto.Message{
+		&anypb.Any{},
+		&anypb.Any{TypeUrl: "type.googleapis.com/google.protobuf.Empty"},
+		&api.KnownTypes{AnyField: &anypb.Any{}},
+	}
+
+	for _, message := range testCases {
+		if _, err := hasher.ObjectHash(message); err == nil {
+			t.Errorf("Objecthashing %T{ %[1]v } did not return an error", message)
+		}
+	}
+}
+
+func mustStruct(t *testing.T, m map[string]interface{}) *structpb.Struct {
+	t.Helper()
+
+	s, err := structpb.NewStruct(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
@@ -0,0 +1,219 @@
+package tests
+
+import (
+	"testing"
+
+	ph "github.com/aserto-dev/go-protohash"
+	pb2 "github.com/aserto-dev/go-protohash/tests/api/proto2/v1"
+	"github.com/aserto-dev/go-protohash/tests/api/v1"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestWireFormat checks that hashing the wire format of a message gives the
+// same result as hashing the decoded message, however it was serialized.
+func TestWireFormat(t *testing.T, hasher *ph.ProtoHasher) {
+
+	testCases := []struct {
+		message proto.Message
+		wire    []byte
+	}{
+		/////////////////////////////////
+		//  Packed and unpacked lists. //
+		/////////////////////////////////
+		{
+			message: &api.Repetitive{Int32Field: []int32{1, 2, 3}},
+			wire:    appendPacked(nil, 13, 1, 2, 3),
+		},
+
+		{
+			message: &api.Repetitive{Int32Field: []int32{1, 2, 3}},
+			wire:    appendVarint(appendVarint(appendVarint(nil, 13, 1), 13, 2), 13, 3),
+		},
+
+		{
+			message: &api.Repetitive{Int32Field: []int32{1, 2, 3}},
+			wire:    appendVarint(appendPacked(nil, 13, 1, 2), 13, 3),
+		},
+
+		// An empty packed list is not populated.
+		{
+			message: &api.Repetitive{},
+			wire:    appendPacked(nil, 13),
+		},
+
+		///////////////////
+		//  Field order. //
+		///////////////////
+		{
+			message: &api.Simple{Int64Field: 1, StringField: "foo"},
+			wire:    appendString(appendVarint(nil, 15, 1), 25, "foo"),
+		},
+
+		{
+			message: &api.Simple{Int64Field: 1, StringField: "foo"},
+			wire:    appendVarint(appendString(nil, 25, "foo"), 15, 1),
+		},
+
+		//////////////////////
+		//  Last one wins. //
+		//////////////////////
+		{
+			message: &api.Simple{Int64Field: 2},
+			wire:    appendVarint(appendVarint(nil, 15, 1), 15, 2),
+		},
+
+		// The last value is the zero value, so the proto3 field is not populated.
+		{
+			message: &api.Simple{},
+			wire:    appendVarint(appendVarint(nil, 15, 1), 15, 0),
+		},
+
+		{
+			message: &pb2.Simple{Int64Field: proto.Int64(0)},
+			wire:    appendVarint(appendVarint(nil, 15, 1), 15, 0),
+		},
+
+		// Occurrences of a message field are merged.
+		{
+			message: &api.Simple{SimpleField: &api.Simple{Int64Field: 2, StringField: "foo"}},
+			wire: appendMessage(appendMessage(nil, 31, appendVarint(appendString(nil, 25, "foo"), 15, 1)),
+				31, appendVarint(nil, 15, 2)),
+		},
+
+		// Setting a member of a oneof clears the others.
+		{
+			message: &api.Singleton{Singleton: &api.Singleton_TheInt64{TheInt64: 0}},
+			wire:    appendVarint(appendString(nil, 25, "foo"), 15, 0),
+		},
+
+		{
+			message: &api.Singleton{Singleton: &api.Singleton_TheSimple{TheSimple: &api.Simple{Int64Field: 1}}},
+			wire: appendMessage(appendString(appendMessage(nil, 31, appendString(nil, 25, "foo")), 25, "bar"),
+				31, appendVarint(nil, 15, 1)),
+		},
+
+		// The last entry with a given key wins.
+		{
+			message: &api.StringMaps{StringToString: map[string]string{"foo": "baz", "qux": ""}},
+			wire: appendMessage(appendMessage(appendMessage(nil,
+				13, appendString(appendString(nil, 1, "foo"), 2, "bar")),
+				13, appendString(nil, 1, "qux")),
+				13, appendString(appendString(nil, 2, "baz"), 1, "foo")),
+		},
+
+		///////////////////////
+		//  Unknown fields. //
+		///////////////////////
+		{
+			message: &api.Simple{Int64Field: 1},
+			wire:    appendString(appendVarint(appendVarint(nil, 15, 1), 1000, 7), 1001, "unknown"),
+		},
+
+		// A value with the wrong wire type is an unknown field.
+		{
+			message: &api.Simple{Int64Field: 1},
+			wire:    appendString(appendVarint(nil, 15, 1), 15, "foo"),
+		},
+
+		// Like proto.Unmarshal, unknown values of closed enums are kept.
+		{
+			message: &pb2.Simple{ColorField: pb2.Color(99).Enum()},
+			wire:    appendVarint(nil, 37, 99),
+		},
+
+		{
+			message: &pb2.Simple{ColorField: pb2.Color(99).Enum()},
+			wire:    appendVarint(appendVarint(nil, 37, 1), 37, 99),
+		},
+
+		{
+			message: &pb2.Simple{ColorField: pb2.Color_COLOR_RED.Enum()},
+			wire:    appendVarint(appendVarint(nil, 37, 99), 37, 1),
+		},
+	}
+
+	for _, tc := range testCases {
+		// Make sure that the test case is correct. HashMessage ignores unknown
+		// fields, so they are discarded.
+		decoded := tc.message.ProtoReflect().New().Interface()
+		if err := (proto.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(tc.wire, decoded); err != nil {
+			t.Fatalf("Attempting to unmarshal %x returned an error: %v", tc.wire, err)
+		}
+		if !proto.Equal(decoded, tc.message) {
+			t.Fatalf("%x decodes to %T{ %[2]v }, not %T{ %[3]v }", tc.wire, decoded, tc.message)
+		}
+
+		expected, err := hasher.HashMessage(tc.message)
+		if err != nil {
+			t.Fatalf("Attempting to hash %T{ %[1]v } returned an error: %v", tc.message, err)
+		}
+
+		actual, err := hasher.HashWire(tc.message.ProtoReflect().Descriptor(), tc.wire)
+		if err != nil {
+			t.Errorf("Attempting to hash %x returned an error: %v", tc.wire, err)
+		} else if actual != expected {
+			t.Errorf("Got the wrong hash for %x, which decodes to %T{ %[2]v }.\n"+
+				"Actual:   %x\nExpected: %x\n", tc.wire, tc.message, actual, expected)
+		}
+	}
+
+	// Closed enums hash alike in both formats with unknown fields too.
+	withUnknown := ph.New(ph.WithAlgorithm(hasher.Algorithm()), ph.WithUnknownFields())
+	closed := &pb2.Simple{ColorField: pb2.Color(99).Enum()}
+	expected, err := withUnknown.HashMessage(closed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual, err := withUnknown.HashWire(closed.ProtoReflect().Descriptor(), appendVarint(nil, 37, 99))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual != expected {
+		t.Errorf("Got the wrong hash for an unknown value of a closed enum with unknown fields.\n"+
+			"Actual:   %x\nExpected: %x\n", actual, expected)
+	}
+
+	invalidCases := [][]byte{
+		// Truncated varint.
+		{0x78, 0x80},
+		// Truncated string.
+		appendString(nil, 25, "foo")[:4],
+		// Invalid UTF-8 in a proto3 string.
+		appendString(nil, 25, "\xff"),
+	}
+
+	for _, wire := range invalidCases {
+		if _, err := hasher.HashWire((&api.Simple{}).ProtoReflect().Descriptor(), wire); err == nil {
+			t.Errorf("Hashing %x did not return an error", wire)
+		}
+	}
+
+	// Required fields are checked in the wire format too.
+	if _, err := hasher.HashWire((&pb2.Required{}).ProtoReflect().Descriptor(), nil); err == nil {
+		t.Errorf("Hashing a %T with missing required fields did not return an error", &pb2.Required{})
+	}
+}
+
+func appendVarint(b []byte, num protowire.Number, v uint64) []byte {
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, v)
+}
+
+func appendString(b []byte, num protowire.Number, s string) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, s)
+}
+
+func appendMessage(b []byte, num protowire.Number, m []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, m)
+}
+
+func appendPacked(b []byte, num protowire.Number, vs ...uint64) []byte {
+	var packed []byte
+	for _, v := range vs {
+		packed = protowire.AppendVarint(packed, v)
+	}
+	return appendMessage(b, num, packed)
+}
@@ -0,0 +1,166 @@
+package protohash
+
+import (
+	"sort"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protopath"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// HashNode is a node of the tree returned by HashTree. It holds the hash of
+// the value reached from its parent by Step: the hashed message for the root,
+// a field, a list element or a map entry.
+type HashNode struct {
+	// Step is a protopath.Root step for the root, and a protopath.FieldAccess,
+	// protopath.ListIndex or protopath.MapIndex step for the other nodes.
+	Step protopath.Step
+
+	// Hash is the hash of the message for the root, the hash of the value of
+	// a field, before it is combined with the other fields, the hash of a list
+	// element, or the hash of a map entry, which covers both its key and value.
+	Hash uint64
+
+	// Children are the populated fields of a message value, in field number
+	// order, the elements of a list, in order, or the entries of a map, in key
+	// order. Well-known types with their own semantics, such as Timestamp,
+	// Struct or Any, are hashed as a whole and have no children.
+	Children []*HashNode
+}
+
+// HashTree returns the hash of msg, as HashMessage does, along with the hashes
+// of the fields, list elements and map entries it was computed from, as a tree
+// that mirrors the structure of msg. The tree is built through reflection,
+// even when msg implements Hashable.
+func (ph *ProtoHasher) HashTree(msg proto.Message) (*HashNode, error) {
+	m, err := ph.reflectMessage(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ph.checkOptions(); err != nil {
+		return nil, err
+	}
+
+	hs := ph.acquireState()
+	defer ph.releaseState(hs)
+
+	if err := hs.selectPaths(m.Descriptor()); err != nil {
+		return nil, err
+	}
+
+	root := &HashNode{Step: protopath.Root(m.Descriptor())}
+	hs.tree = []*HashNode{root}
+	defer func() { hs.tree = nil }()
+
+	root.Hash, err = hs.hashMessage(m)
+	if err != nil {
+		return nil, rootError(err, m.Descriptor())
+	}
+
+	root.sort()
+	return root, nil
+}
+
+// Diff returns the paths of the smallest subtrees that differ between the
+// trees a and b, that is the nodes whose hashes differ while their children
+// are identical, or that are present in only one of the trees.
+func Diff(a, b *HashNode) []protopath.Path {
+	return diff(protopath.Path{a.Step}, a, b)
+}
+
+func diff(path protopath.Path, a, b *HashNode) []protopath.Path {
+	if a.Hash == b.Hash {
+		return nil
+	}
+
+	children := make(map[string]*HashNode, len(b.Children))
+	for _, child := range b.Children {
+		children[child.Step.String()] = child
+	}
+
+	var paths []protopath.Path
+	for _, child := range a.Children {
+		childPath := append(path[:len(path):len(path)], child.Step)
+		other, ok := children[child.Step.String()]
+		if !ok {
+			paths = append(paths, childPath)
+			continue
+		}
+		delete(children, child.Step.String())
+		paths = append(paths, diff(childPath, child, other)...)
+	}
+	for _, child := range b.Children {
+		if _, ok := children[child.Step.String()]; ok {
+			paths = append(paths, append(path[:len(path):len(path)], child.Step))
+		}
+	}
+
+	if len(paths) == 0 {
+		return []protopath.Path{path}
+	}
+	return paths
+}
+
+// sort sorts the children of the nodes of the tree: the elements of a list
+// are hashed from the last to the first, and maps are ranged in no specific
+// order.
+func (n *HashNode) sort() {
+	sort.SliceStable(n.Children, func(i, j int) bool {
+		a, b := n.Children[i].Step, n.Children[j].Step
+		switch {
+		case a.Kind() == protopath.ListIndexStep && b.Kind() == protopath.ListIndexStep:
+			return a.ListIndex() < b.ListIndex()
+		case a.Kind() == protopath.MapIndexStep && b.Kind() == protopath.MapIndexStep:
+			return lessMapKey(a.MapIndex(), b.MapIndex())
+		default:
+			return false
+		}
+	})
+	for _, child := range n.Children {
+		child.sort()
+	}
+}
+
+func lessMapKey(a, b protoreflect.MapKey) bool {
+	switch v := a.Interface().(type) {
+	case bool:
+		return !v && b.Bool()
+	case int32, int64:
+		return a.Int() < b.Int()
+	case uint32, uint64:
+		return a.Uint() < b.Uint()
+	default:
+		return a.String() < b.String()
+	}
+}
+
+// enterNode starts recording the hash of the value reached by step, when
+// HashTree is building a tree.
+func (hs *hashState) enterNode(step protopath.Step) {
+	parent := hs.tree[len(hs.tree)-1]
+	node := &HashNode{Step: step}
+	parent.Children = append(parent.Children, node)
+	hs.tree = append(hs.tree, node)
+}
+
+// leaveNode records the hash h of the value entered last with enterNode.
+func (hs *hashState) leaveNode(h uint64) {
+	hs.tree[len(hs.tree)-1].Hash = h
+	hs.tree = hs.tree[:len(hs.tree)-1]
+}
+
+// hashWellKnown hashes msg, a well-known type with its own semantics, as a
+// leaf of the tree: its hash is not computed from values that can be reached
+// by steps of its descriptor, such as the entries of a Struct or the unpacked
+// message of an Any.
+func (hs *hashState) hashWellKnown(plan *messagePlan, msg protoreflect.Message) (uint64, error) {
+	if hs.tree == nil {
+		return plan.wellKnown(hs, msg)
+	}
+
+	tree := hs.tree
+	hs.tree = nil
+	defer func() { hs.tree = tree }()
+	return plan.wellKnown(hs, msg)
+}
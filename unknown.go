@@ -0,0 +1,155 @@
+package protohash
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/reflect/protopath"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// WithUnknownFields mixes the unknown fields of messages, such as those set by
+// a newer version of their schema, into their hash, so that messages that only
+// differ by data the hasher does not know about no longer collide. Messages
+// without unknown fields hash as they do without this option.
+//
+// Unknown fields are parsed from their wire format and sorted by field number
+// and wire type, keeping the order of the values of each field. Varints and
+// fixed values are hashed as integers, length-delimited values as bytes, and
+// groups as messages of unknown fields. Every field is bound to its number and
+// wire type, and the unknown fields as a whole are mixed into the hash of the
+// message after its known fields.
+//
+// Unknown fields are left out of the messages whose fields are selected by
+// WithFieldMask, as they cannot be addressed by a path.
+func WithUnknownFields() HashOption {
+	return func(ph *ProtoHasher) {
+		ph.unknownFields = true
+	}
+}
+
+// unknownField is a value of an unknown field, as parsed from the wire format.
+type unknownField struct {
+	tag uint64
+	v   uint64
+	b   []byte
+}
+
+// hashUnknown mixes the unknown fields b of a message into its hash h.
+func (hs *hashState) hashUnknown(h uint64, b protoreflect.RawFields) (uint64, error) {
+	if !hs.unknownFields || len(b) == 0 || hs.include != nil {
+		return h, nil
+	}
+
+	if hs.tree != nil {
+		hs.enterNode(protopath.UnknownAccess())
+	}
+	hv, err := hs.hashUnknownFields(b)
+	if err != nil {
+		return 0, err
+	}
+	if hs.tree != nil {
+		hs.leaveNode(hv)
+	}
+
+	hv, err = hs.hashUpdateNumber(unknownFieldsNumber, hv)
+	if err != nil {
+		return 0, err
+	}
+	return hs.hashUpdateOrdered(h, hv)
+}
+
+// hashUnknownFields combines the hashes of the unknown fields b, by field
+// number and wire type.
+func (hs *hashState) hashUnknownFields(b []byte) (uint64, error) {
+	fields, err := parseUnknown(b)
+	if err != nil {
+		return 0, err
+	}
+	sort.SliceStable(fields, func(i, j int) bool {
+		return fields[i].tag < fields[j].tag
+	})
+
+	var h uint64
+	for start := 0; start < len(fields); {
+		// The values of a field are combined in order, as a list.
+		end := start + 1
+		for end < len(fields) && fields[end].tag == fields[start].tag {
+			end++
+		}
+
+		var hl uint64
+		for _, f := range fields[start:end] {
+			hv, err := hs.hashUnknownValue(f)
+			if err != nil {
+				return 0, err
+			}
+			hl, err = hs.hashUpdateOrdered(hl, hv)
+			if err != nil {
+				return 0, err
+			}
+		}
+
+		hl, err = hs.hashUpdateNumber(fields[start].tag, hl)
+		if err != nil {
+			return 0, err
+		}
+		h, err = hs.hashUpdateOrdered(h, hl)
+		if err != nil {
+			return 0, err
+		}
+		start = end
+	}
+	return h, nil
+}
+
+func (hs *hashState) hashUnknownValue(f unknownField) (uint64, error) {
+	_, typ := protowire.DecodeTag(f.tag)
+	switch typ {
+	case protowire.VarintType, protowire.Fixed64Type:
+		return hs.hashUint(f.v)
+	case protowire.Fixed32Type:
+		return hs.hashFixed(uintIdentifier, 4, f.v)
+	case protowire.StartGroupType:
+		return hs.hashUnknownFields(f.b)
+	default:
+		return hs.hashBytes(f.b)
+	}
+}
+
+// parseUnknown parses the unknown fields b.
+func parseUnknown(b []byte) ([]unknownField, error) {
+	var fields []unknownField
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return nil, errors.Wrap(protowire.ParseError(n), "cannot decode unknown fields")
+		}
+		b = b[n:]
+
+		f := unknownField{tag: protowire.EncodeTag(num, typ)}
+		switch typ {
+		case protowire.VarintType:
+			f.v, n = protowire.ConsumeVarint(b)
+		case protowire.Fixed32Type:
+			var v uint32
+			v, n = protowire.ConsumeFixed32(b)
+			f.v = uint64(v)
+		case protowire.Fixed64Type:
+			f.v, n = protowire.ConsumeFixed64(b)
+		case protowire.BytesType:
+			f.b, n = protowire.ConsumeBytes(b)
+		case protowire.StartGroupType:
+			f.b, n = protowire.ConsumeGroup(num, b)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return nil, errors.Wrap(protowire.ParseError(n), "cannot decode unknown fields")
+		}
+		b = b[n:]
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
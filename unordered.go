@@ -0,0 +1,130 @@
+package protohash
+
+import (
+	"bytes"
+	"sort"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ListMode selects how the elements of a repeated field are combined.
+type ListMode int
+
+const (
+	// ListOrdered combines the elements in order. It is the default mode.
+	ListOrdered ListMode = iota
+
+	// ListSet ignores the order of the elements and their duplicates, so that
+	// [a, b, a] hashes like [b, a].
+	ListSet
+
+	// ListMultiset ignores the order of the elements but not their duplicates,
+	// so that [a, b, a] hashes like [a, a, b] but not like [a, b].
+	ListMultiset
+)
+
+// WithUnorderedFields hashes the repeated fields with the given full names, such
+// as "tests.api.v1.Repetitive.string_field", as sets or multisets. Their element
+// hashes are hardened and added together, which unlike the XOR used for map
+// entries does not cancel out duplicates, and the sum is hardened like that of
+// a map.
+//
+// Fields that are not repeated are not affected. The mode given here overrides
+// the one declared with (protohash.field).unordered. Messages with unordered
+// fields are hashed through reflection rather than by their generated ProtoHash
+// method.
+func WithUnorderedFields(mode ListMode, fields ...protoreflect.FullName) HashOption {
+	return func(ph *ProtoHasher) {
+		if ph.listModes == nil {
+			ph.listModes = make(map[protoreflect.FullName]ListMode, len(fields))
+		}
+		for _, name := range fields {
+			ph.listModes[name] = mode
+		}
+	}
+}
+
+// listMode returns the mode of the repeated field fd, as given to
+// WithUnorderedFields or else declared with (protohash.field).unordered.
+func (ph *ProtoHasher) listMode(fd protoreflect.FieldDescriptor) ListMode {
+	if !fd.IsList() {
+		return ListOrdered
+	}
+	if mode, ok := ph.listModes[fd.FullName()]; ok {
+		return mode
+	}
+	return listModeOption(fieldOptions(fd))
+}
+
+// hashUnorderedList combines the element hashes pushed on hs.elems above start,
+// as a set or a multiset, and pops them.
+func (hs *hashState) hashUnorderedList(mode ListMode, start int) (uint64, error) {
+	elems := hs.elems[start:]
+	if mode == ListSet {
+		elems = hs.dedupe(elems)
+	}
+
+	var h uint64
+	for _, hv := range elems {
+		// Element hashes are hardened before they are added, so that elements
+		// hashing to zero, such as empty messages, still count.
+		hv, err := hs.hashFinishUnordered(hv)
+		if err != nil {
+			return 0, err
+		}
+		h = hs.hashUpdateSum(h, hv)
+	}
+	hs.elems = hs.elems[:start]
+
+	return hs.hashFinishUnordered(h)
+}
+
+// dedupe sorts the hashes in elems and drops their duplicates.
+func (hs *hashState) dedupe(elems []uint64) []uint64 {
+	sort.Sort(hashSlice{hs: hs, elems: elems})
+
+	n := 0
+	for i, hv := range elems {
+		if i == 0 || hs.compareHashes(elems[n-1], hv) != 0 {
+			elems[n] = hv
+			n++
+		}
+	}
+	return elems[:n]
+}
+
+// compareHashes compares the hashes a and b, which are digest handles in digest
+// mode.
+func (hs *hashState) compareHashes(a, b uint64) int {
+	if hs.h64 == nil {
+		return bytes.Compare(hs.digests.get(a), hs.digests.get(b))
+	}
+
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// hashSlice sorts hashes with hashState.compareHashes.
+type hashSlice struct {
+	hs    *hashState
+	elems []uint64
+}
+
+func (s hashSlice) Len() int           { return len(s.elems) }
+func (s hashSlice) Less(i, j int) bool { return s.hs.compareHashes(s.elems[i], s.elems[j]) < 0 }
+func (s hashSlice) Swap(i, j int)      { s.elems[i], s.elems[j] = s.elems[j], s.elems[i] }
+
+// hashUpdateSum adds the hashes a and b, modulo 2^64 or, in digest mode, as
+// little-endian integers of the digest size.
+func (hs *hashState) hashUpdateSum(a, b uint64) uint64 {
+	if hs.h64 == nil {
+		return hs.digests.add(a, b)
+	}
+	return a + b
+}
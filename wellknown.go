@@ -0,0 +1,210 @@
+package protohash
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	anyFullName       protoreflect.FullName = "google.protobuf.Any"
+	durationFullName  protoreflect.FullName = "google.protobuf.Duration"
+	listValueFullName protoreflect.FullName = "google.protobuf.ListValue"
+	structFullName    protoreflect.FullName = "google.protobuf.Struct"
+	timestampFullName protoreflect.FullName = "google.protobuf.Timestamp"
+	valueFullName     protoreflect.FullName = "google.protobuf.Value"
+)
+
+// wrapperFullNames are the scalar wrappers of wrappers.proto.
+var wrapperFullNames = map[protoreflect.FullName]bool{
+	"google.protobuf.BoolValue":   true,
+	"google.protobuf.BytesValue":  true,
+	"google.protobuf.DoubleValue": true,
+	"google.protobuf.FloatValue":  true,
+	"google.protobuf.Int32Value":  true,
+	"google.protobuf.Int64Value":  true,
+	"google.protobuf.StringValue": true,
+	"google.protobuf.UInt32Value": true,
+	"google.protobuf.UInt64Value": true,
+}
+
+// Kind tags of the tagged encoding for values that have no ObjectHash type.
+// Unsigned integers, which ObjectHash hashes like signed ones, have their own
+// tag so that they do not collide with the signed integers of the same bits.
+const (
+	timestampIdentifier = 'T'
+	durationIdentifier  = 'D'
+	uintIdentifier      = 'U'
+)
+
+// nullMarker is what a null Value is hashed as without tagged encoding.
+const nullMarker = 0xff
+
+// wellKnownHasher returns the function that hashes the well-known types that
+// have their own semantics, or nil if md is not one of them.
+//
+//   - Timestamp and Duration are hashed as the normalized instant or duration
+//     they denote, so an empty Timestamp is an explicit zero rather than unset.
+//   - Wrappers are hashed as their value, which is present even when zero.
+//   - Struct, Value and ListValue are hashed like the equivalent JSON: a
+//     Struct like a map, a ListValue like a repeated field and a Value like
+//     the value of its kind, with null as a value of its own.
+//   - Any is hashed as the message it holds when the hasher has a resolver.
+func (ph *ProtoHasher) wellKnownHasher(md protoreflect.MessageDescriptor) func(*hashState, protoreflect.Message) (uint64, error) {
+	switch name := md.FullName(); {
+	case name == timestampFullName:
+		return func(hs *hashState, msg protoreflect.Message) (uint64, error) {
+			seconds, nanos := timestampValue(msg)
+			return hs.hashPair(timestampIdentifier, seconds, nanos)
+		}
+
+	case name == durationFullName:
+		return func(hs *hashState, msg protoreflect.Message) (uint64, error) {
+			seconds, nanos := durationValue(msg)
+			return hs.hashPair(durationIdentifier, seconds, nanos)
+		}
+
+	case wrapperFullNames[name]:
+		value := md.Fields().ByName("value")
+		hashValue := ph.quantizedFloatHasher(value, valueHasherFor(value))
+		return func(hs *hashState, msg protoreflect.Message) (uint64, error) {
+			return hashValue(hs, msg.Get(value))
+		}
+
+	case name == structFullName:
+		fields := ph.compileField(md.Fields().ByName("fields"))
+		return func(hs *hashState, msg protoreflect.Message) (uint64, error) {
+			return fields.hash(hs, msg.Get(fields.fd))
+		}
+
+	case name == listValueFullName:
+		values := ph.compileField(md.Fields().ByName("values"))
+		return func(hs *hashState, msg protoreflect.Message) (uint64, error) {
+			return values.hash(hs, msg.Get(values.fd))
+		}
+
+	case name == valueFullName:
+		kind := md.Oneofs().ByName("kind")
+		kinds := make(map[protoreflect.FieldNumber]fieldPlan, kind.Fields().Len())
+		for i := 0; i < kind.Fields().Len(); i++ {
+			fd := kind.Fields().Get(i)
+			if fd.Name() != "null_value" {
+				kinds[fd.Number()] = ph.compileField(fd)
+			}
+		}
+		return func(hs *hashState, msg protoreflect.Message) (uint64, error) {
+			fd := msg.WhichOneof(kind)
+			if fd == nil || fd.Name() == "null_value" {
+				return hs.hashNull()
+			}
+			fp := kinds[fd.Number()]
+			return fp.hash(hs, msg.Get(fd))
+		}
+
+	case name == anyFullName && ph.resolver != nil:
+		return (*hashState).hashAny
+	}
+
+	return nil
+}
+
+// hashNull hashes a null Value. Without tagged encoding, it is hashed as the
+// single byte nullMarker, which no other Value is hashed as: it is not valid
+// UTF-8 and is neither a bool nor a number.
+func (hs *hashState) hashNull() (uint64, error) {
+	b := hs.beginValue(nilIdentifier, 0)
+	if len(b) == 0 {
+		b = append(b, nullMarker)
+	}
+	_, err := hs.h.Write(b)
+	return hs.sum(), err
+}
+
+// hashPair hashes the seconds and nanos of a Timestamp or a Duration.
+func (hs *hashState) hashPair(identifier byte, seconds, nanos int64) (uint64, error) {
+	b := hs.beginValue(identifier, 16)
+	l := len(b)
+	b = b[:l+16]
+	binary.LittleEndian.PutUint64(b[l:], uint64(seconds))
+	binary.LittleEndian.PutUint64(b[l+8:], uint64(nanos))
+	_, err := hs.h.Write(b)
+	return hs.sum(), err
+}
+
+// objectHashWellKnown is the ObjectHash counterpart of wellKnownHasher. Any is
+// rejected unless it can be unpacked, as its raw payload has no ObjectHash
+// representation.
+func (ph *ProtoHasher) objectHashWellKnown(msg protoreflect.Message) ([]byte, bool, error) {
+	fd := msg.Descriptor()
+	switch {
+	case fd.FullName() == timestampFullName:
+		seconds, nanos := timestampValue(msg)
+		return objectHashList([][]byte{objectHashInt(seconds), objectHashInt(nanos)}), true, nil
+
+	case fd.FullName() == durationFullName:
+		seconds, nanos := durationValue(msg)
+		return objectHashList([][]byte{objectHashInt(seconds), objectHashInt(nanos)}), true, nil
+
+	case wrapperFullNames[fd.FullName()]:
+		value := fd.Fields().ByName("value")
+		h, err := ph.objectHashValue(value, msg.Get(value), ph.objectFieldFor(value, nil, nil))
+		return h, true, err
+
+	case fd.FullName() == structFullName:
+		fields := fd.Fields().ByName("fields")
+		h, err := ph.objectHashMap(fields, msg.Get(fields).Map(), objectField{})
+		return h, true, err
+
+	case fd.FullName() == listValueFullName:
+		values := fd.Fields().ByName("values")
+		h, err := ph.objectHashList(values, msg.Get(values).List(), objectField{})
+		return h, true, err
+
+	case fd.FullName() == valueFullName:
+		kind := msg.WhichOneof(fd.Oneofs().ByName("kind"))
+		if kind == nil || kind.Name() == "null_value" {
+			return objectHash(nilIdentifier, nil), true, nil
+		}
+		h, err := ph.objectHashField(kind, msg.Get(kind), ph.objectFieldFor(kind, nil, nil))
+		return h, true, err
+
+	case fd.FullName() == anyFullName:
+		if ph.resolver == nil {
+			return nil, true, errors.Errorf("%s is not supported by ObjectHash without a resolver", anyFullName)
+		}
+		h, err := ph.objectHashAny(msg)
+		return h, true, err
+	}
+
+	return nil, false, nil
+}
+
+// timestampValue returns the seconds and nanos of a Timestamp, normalized so
+// that nanos is in [0, 1e9).
+func timestampValue(msg protoreflect.Message) (int64, int64) {
+	fields := msg.Descriptor().Fields()
+	t := time.Unix(msg.Get(fields.ByName("seconds")).Int(), msg.Get(fields.ByName("nanos")).Int())
+	return t.Unix(), int64(t.Nanosecond())
+}
+
+// durationValue returns the seconds and nanos of a Duration, normalized so that
+// nanos is in (-1e9, 1e9) and has the sign of seconds.
+func durationValue(msg protoreflect.Message) (int64, int64) {
+	fields := msg.Descriptor().Fields()
+	seconds := msg.Get(fields.ByName("seconds")).Int()
+	nanos := msg.Get(fields.ByName("nanos")).Int()
+
+	seconds += nanos / int64(time.Second)
+	nanos %= int64(time.Second)
+	switch {
+	case seconds > 0 && nanos < 0:
+		seconds--
+		nanos += int64(time.Second)
+	case seconds < 0 && nanos > 0:
+		seconds++
+		nanos -= int64(time.Second)
+	}
+	return seconds, nanos
+}
@@ -0,0 +1,478 @@
+package protohash
+
+import (
+	"math"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// HashWire returns the hash of the message described by desc and serialized in
+// b, without unmarshalling it. The result is the same as that of HashMessage on
+// the decoded message: fields may appear in any order, repeated scalars may be
+// packed or not, the last value of a scalar field wins and the occurrences of a
+// message field are merged.
+//
+// Extensions are decoded with protoregistry.GlobalTypes, or the resolver given
+// to WithExtensionResolver, and unknown fields, including the extensions it
+// does not know, are ignored unless WithUnknownFields is used. Well-known types
+// are decoded before they are hashed, as they are hashed by their semantics.
+func (ph *ProtoHasher) HashWire(desc protoreflect.MessageDescriptor, b []byte) (uint64, error) {
+	if desc == nil {
+		return 0, errors.New("desc is nil")
+	}
+
+	if err := ph.checkOptions(); err != nil {
+		return 0, err
+	}
+
+	hs := ph.acquireState()
+	defer ph.releaseState(hs)
+
+	if err := hs.selectPaths(desc); err != nil {
+		return 0, err
+	}
+
+	h, err := hs.hashWire(desc, b)
+	if err != nil {
+		return 0, rootError(err, desc)
+	}
+	return h, nil
+}
+
+// wireValue is a value decoded from the wire format. Messages are kept in their
+// serialized form in b, and are only decoded when they are hashed.
+type wireValue struct {
+	v protoreflect.Value
+	b []byte
+}
+
+// wireField holds the values of a field decoded from the wire format. list has
+// the elements of a repeated field, the entry values of a map or the single
+// value of any other field. keys has the keys of the map entries, and index the
+// position of each key in keys.
+type wireField struct {
+	fd    protoreflect.FieldDescriptor
+	list  []wireValue
+	keys  []protoreflect.Value
+	index map[interface{}]int
+}
+
+// populated reports whether the field is populated, as reported by
+// protoreflect.Message.Has on the decoded message.
+func (wf *wireField) populated() bool {
+	switch {
+	case wf.fd.IsList(), wf.fd.IsMap():
+		return len(wf.list) > 0
+	case wf.fd.HasPresence():
+		return true
+	}
+
+	v := wf.list[0].v
+	switch wf.fd.Kind() {
+	case protoreflect.BoolKind:
+		return v.Bool()
+	case protoreflect.EnumKind:
+		return v.Enum() != 0
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed32Kind, protoreflect.Sfixed64Kind:
+		return v.Int() != 0
+	case protoreflect.Uint32Kind, protoreflect.Uint64Kind, protoreflect.Fixed32Kind, protoreflect.Fixed64Kind:
+		return v.Uint() != 0
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return math.Float64bits(v.Float()) != 0
+	case protoreflect.StringKind:
+		return len(v.String()) > 0
+	case protoreflect.BytesKind:
+		return len(v.Bytes()) > 0
+	default:
+		return true
+	}
+}
+
+func (hs *hashState) hashWire(md protoreflect.MessageDescriptor, b []byte) (uint64, error) {
+	if hs.plan(md).wellKnown != nil {
+		m := dynamicpb.NewMessage(md)
+		opts := proto.UnmarshalOptions{AllowPartial: hs.allowPartial}
+		if err := opts.Unmarshal(b, m); err != nil {
+			return 0, err
+		}
+		return hs.hashMessage(m)
+	}
+
+	fields, unknown, err := decodeWire(md, b, hs.wireExtensionResolver())
+	if err != nil {
+		return 0, err
+	}
+
+	if !hs.allowPartial {
+		required := md.RequiredNumbers()
+		for i := 0; i < required.Len(); i++ {
+			if _, ok := fields[required.Get(i)]; !ok {
+				return 0, invalidMessage("required field %s not set", md.Fields().ByNumber(required.Get(i)).FullName())
+			}
+		}
+	}
+
+	ordered := make([]*wireField, 0, len(fields))
+	for _, wf := range fields {
+		if wf.populated() {
+			ordered = append(ordered, wf)
+		}
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		return ordered[i].fd.Number() < ordered[j].fd.Number()
+	})
+
+	plan := hs.plan(md)
+	parentInclude, parentExclude := hs.include, hs.exclude
+
+	var h, ext uint64
+	var extended bool
+	for _, wf := range ordered {
+		fp := hs.planField(plan, wf.fd)
+		include, exclude, ok := selectField(hs.include, hs.exclude, wf.fd)
+		if fp.ignore || !ok || fp.unset(wf.list[0].v) || hs.skipExtension(wf.fd) {
+			continue
+		}
+
+		hs.include, hs.exclude = include, exclude
+		hv, err := hs.hashWireField(&fp, wf)
+		hs.include, hs.exclude = parentInclude, parentExclude
+		if err != nil {
+			return 0, fieldError(err, wf.fd)
+		}
+
+		if wf.fd.IsExtension() {
+			ext, err = hs.addExtension(ext, wf.fd, hv)
+			extended = true
+		} else {
+			h, err = fp.combine(hs, h, hv)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if extended {
+		h, err = hs.hashExtensions(h, ext)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	h, err = hs.hashUnknown(h, unknown)
+	if err != nil {
+		return 0, err
+	}
+
+	if plan.includeTypeName {
+		return hs.hashTypeName(md.FullName(), h)
+	}
+	return h, nil
+}
+
+// hashWireField hashes the values of a field decoded from the wire format, as
+// fp.hash does for the decoded field.
+func (hs *hashState) hashWireField(fp *fieldPlan, wf *wireField) (uint64, error) {
+	switch {
+	case fp.shape == listField && fp.listMode != ListOrdered:
+		start := len(hs.elems)
+		for i := range wf.list {
+			hv, err := hs.hashWireValue(wf.fd, fp.value, wf.list[i])
+			if err != nil {
+				hs.elems = hs.elems[:start]
+				return 0, listIndexError(err, wf.fd, i)
+			}
+			hs.elems = append(hs.elems, hv)
+		}
+		return hs.hashUnorderedList(fp.listMode, start)
+
+	case fp.shape == listField:
+		var h uint64
+		for i := len(wf.list) - 1; i >= 0; i-- {
+			hv, err := hs.hashWireValue(wf.fd, fp.value, wf.list[i])
+			if err != nil {
+				return 0, listIndexError(err, wf.fd, i)
+			}
+
+			h, err = hs.hashUpdateOrdered(h, hv)
+			if err != nil {
+				return 0, err
+			}
+		}
+		return h, nil
+
+	case fp.shape == mapField:
+		var h uint64
+		for i, k := range wf.keys {
+			hk, err := fp.key(hs, k)
+			if err != nil {
+				return 0, mapIndexError(err, wf.fd, k.MapKey())
+			}
+
+			hv, err := hs.hashWireValue(wf.fd.MapValue(), fp.value, wf.list[i])
+			if err != nil {
+				return 0, mapIndexError(err, wf.fd, k.MapKey())
+			}
+
+			fieldHash, err := hs.hashUpdateOrdered(hk, hv)
+			if err != nil {
+				return 0, err
+			}
+
+			h = hs.hashUpdateUnordered(h, fieldHash)
+		}
+		return hs.hashFinishUnordered(h)
+
+	default:
+		return hs.hashWireValue(wf.fd, fp.value, wf.list[0])
+	}
+}
+
+// hashWireValue hashes a singular value of the field fd, decoded from the wire
+// format. Messages are decoded and hashed recursively, and other values are
+// hashed with value.
+func (hs *hashState) hashWireValue(fd protoreflect.FieldDescriptor, value valueHasher, wv wireValue) (uint64, error) {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return hs.hashWire(fd.Message(), wv.b)
+	default:
+		return value(hs, wv.v)
+	}
+}
+
+// decodeWire decodes the fields of a message from b, applying the merge rules
+// of proto.Unmarshal, and returns them along with its unknown fields. Message
+// values are not decoded. Extensions are decoded when resolver, which may be
+// nil, knows them.
+func decodeWire(md protoreflect.MessageDescriptor, b []byte, resolver protoregistry.ExtensionTypeResolver) (map[protoreflect.FieldNumber]*wireField, protoreflect.RawFields, error) {
+	fields := make(map[protoreflect.FieldNumber]*wireField)
+	var unknown protoreflect.RawFields
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return nil, nil, errors.Wrapf(protowire.ParseError(n), "cannot decode %s", md.FullName())
+		}
+		field := b
+		b = b[n:]
+
+		fd := md.Fields().ByNumber(num)
+		if fd == nil && resolver != nil && md.ExtensionRanges().Has(num) {
+			if xt, err := resolver.FindExtensionByNumber(md.FullName(), num); err == nil {
+				fd = xt.TypeDescriptor()
+			}
+		}
+		packed := fd != nil && fd.IsList() && typ == protowire.BytesType && wireType(fd.Kind()) != protowire.BytesType
+		if fd == nil || (typ != wireType(fd.Kind()) && !packed) {
+			// Unknown field.
+			n = protowire.ConsumeFieldValue(num, typ, b)
+			if n < 0 {
+				return nil, nil, errors.Wrapf(protowire.ParseError(n), "cannot decode %s", md.FullName())
+			}
+			b = b[n:]
+			unknown = append(unknown, field[:len(field)-len(b)]...)
+			continue
+		}
+
+		n, err := decodeWireField(fields, fd, typ, packed, b)
+		if err != nil {
+			return nil, nil, fieldError(err, fd)
+		}
+		b = b[n:]
+
+		// Setting a member of a oneof clears the others.
+		if od := fd.ContainingOneof(); od != nil {
+			for i := 0; i < od.Fields().Len(); i++ {
+				if other := od.Fields().Get(i); other != fd {
+					delete(fields, other.Number())
+				}
+			}
+		}
+	}
+	return fields, unknown, nil
+}
+
+// decodeWireField decodes one occurrence of the field fd from b into fields and
+// returns the number of bytes it consumed.
+func decodeWireField(fields map[protoreflect.FieldNumber]*wireField, fd protoreflect.FieldDescriptor, typ protowire.Type, packed bool, b []byte) (int, error) {
+	wf := fields[fd.Number()]
+	if wf == nil {
+		wf = &wireField{fd: fd}
+		fields[fd.Number()] = wf
+	}
+
+	switch {
+	case packed:
+		v, n := protowire.ConsumeBytes(b)
+		if n < 0 {
+			return 0, protowire.ParseError(n)
+		}
+		for len(v) > 0 {
+			x, m, err := decodeWireScalar(fd, wireType(fd.Kind()), v)
+			if err != nil {
+				return 0, listIndexError(err, fd, len(wf.list))
+			}
+			wf.list = append(wf.list, wireValue{v: x})
+			v = v[m:]
+		}
+		return n, nil
+
+	case fd.Kind() == protoreflect.MessageKind || fd.Kind() == protoreflect.GroupKind:
+		var (
+			v []byte
+			n int
+		)
+		if typ == protowire.StartGroupType {
+			v, n = protowire.ConsumeGroup(fd.Number(), b)
+		} else {
+			v, n = protowire.ConsumeBytes(b)
+		}
+		if n < 0 {
+			return 0, protowire.ParseError(n)
+		}
+
+		switch {
+		case fd.IsMap():
+			return n, wf.addEntry(v)
+		case fd.IsList() || len(wf.list) == 0:
+			wf.list = append(wf.list, wireValue{b: v})
+		default:
+			// The occurrences of a singular message are merged, which is the
+			// same as decoding their concatenation.
+			merged := make([]byte, 0, len(wf.list[0].b)+len(v))
+			merged = append(append(merged, wf.list[0].b...), v...)
+			wf.list[0].b = merged
+		}
+		return n, nil
+
+	default:
+		x, n, err := decodeWireScalar(fd, typ, b)
+		if err != nil {
+			if fd.IsList() {
+				return 0, listIndexError(err, fd, len(wf.list))
+			}
+			return 0, err
+		}
+		switch {
+		case fd.IsList() || len(wf.list) == 0:
+			wf.list = append(wf.list, wireValue{v: x})
+		default:
+			wf.list[0] = wireValue{v: x}
+		}
+		return n, nil
+	}
+}
+
+// addEntry decodes a map entry and adds it to the map, replacing any previous
+// entry with the same key.
+func (wf *wireField) addEntry(b []byte) error {
+	entry, _, err := decodeWire(wf.fd.Message(), b, nil)
+	if err != nil {
+		return err
+	}
+
+	kd, vd := wf.fd.MapKey(), wf.fd.MapValue()
+	k := kd.Default()
+	if f := entry[kd.Number()]; f != nil {
+		k = f.list[0].v
+	}
+
+	var v wireValue
+	switch f := entry[vd.Number()]; {
+	case f != nil:
+		v = f.list[0]
+	case vd.Kind() != protoreflect.MessageKind:
+		v.v = vd.Default()
+	}
+
+	if wf.index == nil {
+		wf.index = make(map[interface{}]int)
+	}
+	if i, ok := wf.index[k.Interface()]; ok {
+		wf.list[i] = v
+		return nil
+	}
+	wf.index[k.Interface()] = len(wf.keys)
+	wf.keys = append(wf.keys, k)
+	wf.list = append(wf.list, v)
+	return nil
+}
+
+// decodeWireScalar decodes a scalar value of the field fd from b. Like
+// proto.Unmarshal, it keeps the unknown values of closed enums.
+func decodeWireScalar(fd protoreflect.FieldDescriptor, typ protowire.Type, b []byte) (protoreflect.Value, int, error) {
+	var (
+		v   uint64
+		raw []byte
+		n   int
+	)
+	switch typ {
+	case protowire.VarintType:
+		v, n = protowire.ConsumeVarint(b)
+	case protowire.Fixed32Type:
+		var v32 uint32
+		v32, n = protowire.ConsumeFixed32(b)
+		v = uint64(v32)
+	case protowire.Fixed64Type:
+		v, n = protowire.ConsumeFixed64(b)
+	case protowire.BytesType:
+		raw, n = protowire.ConsumeBytes(b)
+	}
+	if n < 0 {
+		return protoreflect.Value{}, 0, protowire.ParseError(n)
+	}
+
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return protoreflect.ValueOfBool(protowire.DecodeBool(v)), n, nil
+	case protoreflect.EnumKind:
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(int32(v))), n, nil
+	case protoreflect.Int32Kind, protoreflect.Sfixed32Kind:
+		return protoreflect.ValueOfInt32(int32(v)), n, nil
+	case protoreflect.Sint32Kind:
+		return protoreflect.ValueOfInt32(int32(protowire.DecodeZigZag(v & math.MaxUint32))), n, nil
+	case protoreflect.Int64Kind, protoreflect.Sfixed64Kind:
+		return protoreflect.ValueOfInt64(int64(v)), n, nil
+	case protoreflect.Sint64Kind:
+		return protoreflect.ValueOfInt64(protowire.DecodeZigZag(v)), n, nil
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return protoreflect.ValueOfUint32(uint32(v)), n, nil
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return protoreflect.ValueOfUint64(v), n, nil
+	case protoreflect.FloatKind:
+		return protoreflect.ValueOfFloat32(math.Float32frombits(uint32(v))), n, nil
+	case protoreflect.DoubleKind:
+		return protoreflect.ValueOfFloat64(math.Float64frombits(v)), n, nil
+	case protoreflect.StringKind:
+		if fd.Syntax() == protoreflect.Proto3 && !utf8.Valid(raw) {
+			return protoreflect.Value{}, 0, errors.New("invalid UTF-8")
+		}
+		return protoreflect.ValueOfString(string(raw)), n, nil
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes(raw), n, nil
+	default:
+		return protoreflect.Value{}, 0, errors.Errorf("unknown kind to hash: %s", fd.Kind())
+	}
+}
+
+// wireType returns the wire type of the values of kind k.
+func wireType(k protoreflect.Kind) protowire.Type {
+	switch k {
+	case protoreflect.Fixed32Kind, protoreflect.Sfixed32Kind, protoreflect.FloatKind:
+		return protowire.Fixed32Type
+	case protoreflect.Fixed64Kind, protoreflect.Sfixed64Kind, protoreflect.DoubleKind:
+		return protowire.Fixed64Type
+	case protoreflect.StringKind, protoreflect.BytesKind, protoreflect.MessageKind:
+		return protowire.BytesType
+	case protoreflect.GroupKind:
+		return protowire.StartGroupType
+	default:
+		return protowire.VarintType
+	}
+}